@@ -0,0 +1,503 @@
+package formatters
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dbreedt/pgPretty/helpers"
+	"github.com/dbreedt/pgPretty/interfaces"
+	nodes "github.com/pganalyze/pg_query_go/nodes"
+)
+
+// comment A comment in the sql, the parser drops them so they are found by scanning the sql
+type comment struct {
+	start int
+	end   int
+	text  string
+	// line is set for -- comments, nothing can be printed after them on the same line
+	line bool
+	// ownLine is set when the comment is the first thing on its line
+	ownLine bool
+	// codeAfter is set when the comment is followed by code on its line, i.e. it belongs to that code
+	codeAfter bool
+	// trailing is set when code comes before the comment on its line and none after it
+	trailing bool
+	// anchor is the location of the node the comment trails, -1 when it does not trail a node
+	anchor int
+}
+
+// commentPrinter Prints the end of line comments that are waiting for the line to end in front of every line break
+type commentPrinter struct {
+	interfaces.SqlPrinter
+	df *DefaultFormatter
+	// lineStart is the state after the last line break, nil when a group began or ended since, see printAboveLine
+	lineStart *interfaces.PrinterState
+}
+
+func (cp *commentPrinter) NewLine() {
+	cp.df.flushPendingComments()
+	cp.SqlPrinter.NewLine()
+	cp.startLine()
+}
+
+// SoftBreak Nothing can follow a -- comment on its line, so a soft break after one is a line break, which breaks its
+// group
+func (cp *commentPrinter) SoftBreak(flat string) {
+	for _, c := range cp.df.pendingComments {
+		if c.line {
+			cp.NewLine()
+			return
+		}
+	}
+
+	cp.df.flushPendingComments()
+	cp.SqlPrinter.SoftBreak(flat)
+	cp.startLine()
+}
+
+func (cp *commentPrinter) BeginGroup() {
+	cp.SqlPrinter.BeginGroup()
+	cp.lineStart = nil
+}
+
+func (cp *commentPrinter) EndGroup() {
+	cp.SqlPrinter.EndGroup()
+	cp.lineStart = nil
+}
+
+func (cp *commentPrinter) Restore(state interfaces.PrinterState) {
+	cp.SqlPrinter.Restore(state)
+	cp.lineStart = nil
+}
+
+func (cp *commentPrinter) startLine() {
+	state := cp.SqlPrinter.State()
+	cp.lineStart = &state
+}
+
+// formatterState A snapshot of everything a statement changes while it is printed, see PrintNode and recoverVerbatim
+type formatterState struct {
+	printer         interfaces.PrinterState
+	nextComment     int
+	commentEnd      int
+	pendingComments []comment
+}
+
+func (df *DefaultFormatter) state() formatterState {
+	return formatterState{
+		printer:         df.printer.State(),
+		nextComment:     df.nextComment,
+		commentEnd:      df.commentEnd,
+		pendingComments: append([]comment(nil), df.pendingComments...),
+	}
+}
+
+func (df *DefaultFormatter) restore(state formatterState) {
+	df.printer.Restore(state.printer)
+	df.nextComment = state.nextComment
+	df.commentEnd = state.commentEnd
+	df.pendingComments = state.pendingComments
+}
+
+// scanComments Finds the comments in the sql, skipping over anything quoted
+func scanComments(sql string) []comment {
+	var comments []comment
+
+	for i := 0; i < len(sql); {
+		switch {
+		case isComment(sql, i):
+			end, _ := helpers.LiteralEnd(sql, i)
+
+			comments = append(comments, newComment(sql, i, end))
+			i = end
+
+		case sql[i] == '\'' || sql[i] == '"' || isWordChar(sql[i]):
+			i = tokenEnd(sql, i)
+
+		default:
+			i++
+		}
+	}
+
+	return comments
+}
+
+func newComment(sql string, start, end int) comment {
+	lineStart := strings.LastIndexByte(sql[:start], '\n') + 1
+	lineEnd := strings.IndexByte(sql[end:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(sql)
+	} else {
+		lineEnd += end
+	}
+
+	before := strings.TrimSpace(sql[lineStart:start])
+	after := strings.TrimSpace(sql[end:lineEnd])
+	codeAfter := after != "" && !isComment(after, 0)
+
+	return comment{
+		start:     start,
+		end:       end,
+		text:      strings.TrimRight(sql[start:end], " \t\r"),
+		line:      strings.HasPrefix(sql[start:], "--"),
+		ownLine:   before == "",
+		codeAfter: codeAfter,
+		trailing:  before != "" && !codeAfter,
+		anchor:    -1,
+	}
+}
+
+// skipSpaceAndComments Returns the position of the first token at or after i
+func skipSpaceAndComments(sql string, i int) int {
+	for i < len(sql) {
+		switch {
+		case isSpace(sql[i]):
+			i++
+
+		case isComment(sql, i):
+			i, _ = helpers.LiteralEnd(sql, i)
+
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// anchorComments Finds the node every end of line comment of the statement trails: the last node that starts on the
+// same line before the comment
+func (df *DefaultFormatter) anchorComments(stmt nodes.Node) {
+	var locations []int
+
+	walkLocations(stmt, func(location int) {
+		locations = append(locations, location)
+	})
+
+	sort.Ints(locations)
+
+	for i := df.nextComment; i < len(df.comments) && df.comments[i].start < df.statementEnd; i++ {
+		c := &df.comments[i]
+		if !c.trailing {
+			continue
+		}
+
+		j := sort.SearchInts(locations, c.start) - 1
+		if j >= 0 && locations[j] >= strings.LastIndexByte(df.source[:c.start], '\n')+1 {
+			c.anchor = locations[j]
+		}
+	}
+}
+
+// printLeadingComments Prints the comments that come before location in the sql. Comments on a line of their own get
+// a line of their own, a block comment with code after it stays in front of that code. It returns whether the node
+// at location still has to print its indent
+func (df *DefaultFormatter) printLeadingComments(location int, withIndent bool) bool {
+	for df.nextComment < len(df.comments) && df.comments[df.nextComment].start < location {
+		c := df.comments[df.nextComment]
+		df.nextComment++
+		df.commentEnd = c.end
+
+		output := df.printer.String()
+		line := output[strings.LastIndexByte(output, '\n')+1:]
+
+		switch {
+		case !c.line && c.codeAfter:
+			df.printLines(c.text, c.start, withIndent)
+			df.printer.PrintString(" ")
+			withIndent = false
+
+		case strings.TrimSpace(line) != "" && c.ownLine:
+			if !df.printAboveLine(c, line) {
+				// the comment goes below the line then
+				df.printer.NewLine()
+				df.printLines(c.text, c.start, true)
+				df.printer.NewLine()
+				df.printer.PrintString("", true)
+			}
+
+		case strings.TrimSpace(line) != "" && c.line:
+			// a line comment has to wait for the line to end
+			df.pendingComments = append(df.pendingComments, c)
+
+		case strings.TrimSpace(line) != "":
+			df.printLines(c.text, c.start, false)
+			df.printer.PrintString(" ")
+
+		case line == "":
+			df.printLines(c.text, c.start, withIndent)
+			df.printer.NewLine()
+
+		default:
+			// the indent has been printed already
+			df.printLines(c.text, c.start, false)
+			df.printer.NewLine()
+			df.printer.PrintString("", true)
+		}
+	}
+
+	return withIndent
+}
+
+// blockCommentBefore Checks whether the next comment is a block comment with code after it that comes before
+// location, it belongs to the token that follows it
+func (df *DefaultFormatter) blockCommentBefore(location int) bool {
+	if df.nextComment >= len(df.comments) {
+		return false
+	}
+
+	c := df.comments[df.nextComment]
+
+	return c.start < location && !c.line && c.codeAfter
+}
+
+// printCommentsBeforeKeyword Prints the block comments written in front of the keyword or operator that comes before
+// location, the parser does not record where those are. It returns whether the keyword still has to print its indent
+func (df *DefaultFormatter) printCommentsBeforeKeyword(location int, withIndent bool) bool {
+	for df.blockCommentBefore(location) {
+		keyword := skipSpaceAndComments(df.source, df.comments[df.nextComment].end)
+		if keyword >= location {
+			break
+		}
+
+		withIndent = df.printLeadingComments(keyword, withIndent)
+	}
+
+	return withIndent
+}
+
+// printCommentsBeforeBracket Prints the block comments between the end of the node and the bracket that closes
+// around it, the parser does not record where closing brackets are, so no node would print them
+func (df *DefaultFormatter) printCommentsBeforeBracket(node nodes.Node) {
+	first, last, end := 0, -1, 0
+
+	for df.nextComment < len(df.comments) && !df.comments[df.nextComment].line {
+		c := df.comments[df.nextComment]
+
+		next := skipSpaceAndComments(df.source, c.end)
+		if next >= len(df.source) || (df.source[next] != ')' && df.source[next] != ']') {
+			return
+		}
+
+		if last < 0 {
+			if first, last = nodeSpan(node); last < 0 {
+				return
+			}
+
+			end = tokenEnd(df.source, last)
+		}
+
+		if c.start < end || strings.Trim(df.source[end:c.start], " \t\r\n)]") != "" {
+			return
+		}
+
+		if c.ownLine {
+			df.printer.NewLine()
+			df.printLines(c.text, c.start, true)
+		} else {
+			df.printLines(" "+c.text, c.start, false)
+		}
+
+		df.nextComment++
+		df.commentEnd = c.end
+		end = c.end
+
+		// the comments that trail the node waited for this one
+		df.commentsReached(first, last+1)
+	}
+}
+
+// printAboveLine Moves the code printed on the current line down to make room for a comment above it, it returns
+// false when the line can not be moved
+func (df *DefaultFormatter) printAboveLine(c comment, line string) bool {
+	state := df.printer.State()
+	lineStart := interfaces.PrinterState{Length: state.Length - len(line), Indent: state.Indent}
+
+	// what was printed into open groups is not laid out yet, it can only be moved when no group began on the line
+	if state.Buffered > 0 {
+		cp, ok := df.printer.(*commentPrinter)
+		if !ok || cp.lineStart == nil {
+			return false
+		}
+
+		lineStart = *cp.lineStart
+		lineStart.Indent = state.Indent
+	}
+
+	df.printer.Restore(lineStart)
+
+	// the comments waiting for the end of the line belong to the code that is moved down
+	pending := df.pendingComments
+	df.pendingComments = nil
+
+	df.printer.PrintString(line[:len(line)-len(strings.TrimLeft(line, " \t"))])
+	df.printLines(c.text, c.start, false)
+	df.printer.NewLine()
+	df.printer.PrintString(line)
+
+	df.pendingComments = pending
+
+	return true
+}
+
+// commentsReached Queues the comments that trail the nodes located between from and to, they are printed at the end
+// of the line
+func (df *DefaultFormatter) commentsReached(from, to int) {
+	for df.nextComment < len(df.comments) {
+		c := df.comments[df.nextComment]
+		if c.anchor < from || c.anchor >= to {
+			return
+		}
+
+		df.pendingComments = append(df.pendingComments, c)
+		df.nextComment++
+		df.commentEnd = c.end
+	}
+}
+
+// skipComments Drops the comments before position, they were printed as part of verbatim text or left out with
+// their statement
+func (df *DefaultFormatter) skipComments(position int) {
+	for df.nextComment < len(df.comments) && df.comments[df.nextComment].start < position {
+		df.commentEnd = df.comments[df.nextComment].end
+		df.nextComment++
+	}
+}
+
+// flushPendingComments Prints the comments that wait for the end of the line
+func (df *DefaultFormatter) flushPendingComments() {
+	pending := df.pendingComments
+	df.pendingComments = nil
+
+	for i, c := range pending {
+		if i > 0 && pending[i-1].line {
+			df.printer.NewLine()
+			df.printLines(c.text, c.start, true)
+		} else {
+			df.printLines(" "+c.text, c.start, false)
+		}
+	}
+}
+
+// printStatementComments Prints the comments that trail the statement on the line of its semicolon, and the
+// comments inside it that could not be placed, the ones that started on a line of their own on lines of their own
+func (df *DefaultFormatter) printStatementComments() {
+	lineEnd := strings.IndexByte(df.source[df.statementEnd:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(df.source)
+	} else {
+		lineEnd += df.statementEnd
+	}
+
+	for df.nextComment < len(df.comments) {
+		c := df.comments[df.nextComment]
+		if c.start >= df.statementEnd && (c.start >= lineEnd || !c.trailing) {
+			break
+		}
+
+		// a comment that started on its own line, e.g. after the last line of the statement, keeps a line of its own
+		if c.ownLine {
+			df.flushPendingComments()
+			df.printer.NewLine()
+			df.printLines(c.text, c.start, false)
+		} else {
+			df.pendingComments = append(df.pendingComments, c)
+		}
+
+		df.nextComment++
+		df.commentEnd = c.end
+	}
+
+	df.flushPendingComments()
+}
+
+// printHeaderComments Prints the comments between from and the first token of the next statement on lines of their
+// own, it returns where the gap in front of the statement starts
+func (df *DefaultFormatter) printHeaderComments(from, first int) int {
+	for df.nextComment < len(df.comments) && df.comments[df.nextComment].start < first {
+		c := df.comments[df.nextComment]
+
+		df.printGap(from, c.start)
+		df.printLines(c.text, c.start, false)
+
+		df.nextComment++
+		df.commentEnd = c.end
+		from = c.end
+	}
+
+	return from
+}
+
+// printRemainingComments Prints the comments after the last statement
+func (df *DefaultFormatter) printRemainingComments() {
+	i := df.statementEnd
+	for i = skipSpaceAndComments(df.source, i); i < len(df.source) && df.source[i] == ';'; {
+		i = skipSpaceAndComments(df.source, i+1)
+	}
+
+	if i < len(df.source) {
+		return
+	}
+
+	from := df.statementEnd
+	if df.commentEnd > from {
+		from = df.commentEnd
+	}
+
+	df.printHeaderComments(from, len(df.source))
+}
+
+// printGap Starts a new line for what starts at position in the sql, the author's blank lines between from and
+// position are printed as BlankLines blank lines
+func (df *DefaultFormatter) printGap(from, position int) {
+	if df.printer.State().Length == 0 {
+		return
+	}
+
+	df.printer.NewLine()
+
+	if from > position || !blankLine.MatchString(df.source[from:position]) {
+		return
+	}
+
+	for i := 0; i < df.options.BlankLines; i++ {
+		df.printer.NewLine()
+	}
+}
+
+// leadingLocation Returns where the text of the node starts, -1 for nodes that do not know, e.g. operators that are
+// located at the operator instead of their left operand
+func leadingLocation(node nodes.Node) int {
+	switch n := node.(type) {
+	case nodes.BoolExpr, nodes.NullTest, nodes.BooleanTest, nodes.TypeCast, nodes.CollateClause, nodes.SortBy:
+		return -1
+
+	case nodes.A_Expr:
+		if n.Lexpr != nil {
+			return -1
+		}
+
+	case nodes.SubLink:
+		if n.Testexpr != nil {
+			return -1
+		}
+	}
+
+	return nodeLocation(node)
+}
+
+// nodeLocation Returns the location of the node itself, -1 for nodes without one
+func nodeLocation(node nodes.Node) int {
+	v := reflect.ValueOf(node)
+	if v.Kind() != reflect.Struct {
+		return -1
+	}
+
+	location := v.FieldByName("Location")
+	if !location.IsValid() || location.Kind() != reflect.Int {
+		return -1
+	}
+
+	return int(location.Int())
+}
@@ -2,27 +2,136 @@ package formatters
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	helpers "github.com/dbreedt/pgPretty/helpers"
 	interfaces "github.com/dbreedt/pgPretty/interfaces"
+	printers "github.com/dbreedt/pgPretty/printers"
 	nodes "github.com/pganalyze/pg_query_go/nodes"
 )
 
+// Window frame options, see FRAMEOPTION_* in postgres/src/include/nodes/parsenodes.h
+const (
+	frameOptionNonDefault              = 0x00001
+	frameOptionRange                   = 0x00002
+	frameOptionRows                    = 0x00004
+	frameOptionBetween                 = 0x00008
+	frameOptionStartUnboundedPreceding = 0x00010
+	frameOptionEndUnboundedFollowing   = 0x00080
+	frameOptionStartCurrentRow         = 0x00100
+	frameOptionEndCurrentRow           = 0x00200
+	frameOptionStartValuePreceding     = 0x00400
+	frameOptionEndValuePreceding       = 0x00800
+	frameOptionStartValueFollowing     = 0x01000
+	frameOptionEndValueFollowing       = 0x02000
+)
+
+// blankLine Matches a line without anything on it
+var blankLine = regexp.MustCompile(`\n[ \t\r\f]*\n`)
+
+// maxInlineWindowWidth Window specifications that are wider than this are spread over multiple lines
+const maxInlineWindowWidth = 60
+
+// ParameterStyle The placeholder syntax that parameters are printed with
+type ParameterStyle string
+
+const (
+	// ParameterStyleAsWritten prints every parameter the way it was written
+	ParameterStyleAsWritten ParameterStyle = ""
+	// ParameterStylePositional prints numbered parameters as used by libpq and pgx, e.g. $1
+	ParameterStylePositional ParameterStyle = "$n"
+	// ParameterStyleBare prints bare parameters, e.g. ?, statements that use a parameter out of order or more than
+	// once can not be converted
+	ParameterStyleBare ParameterStyle = "?"
+	// ParameterStyleNamed prints go-pg named parameters, e.g. ?name
+	ParameterStyleNamed ParameterStyle = "?name"
+	// ParameterStyleColon prints sqlx named parameters, e.g. :name
+	ParameterStyleColon ParameterStyle = ":name"
+)
+
+// FormatterOptions Optional formatting rules for the DefaultFormatter, the zero value gives the default layout
+type FormatterOptions struct {
+	// AlignValues pads the columns of multi-row values lists so that they line up
+	AlignValues bool
+
+	// KeepParentheses keeps redundant parentheses the author wrote around expressions, the ones the
+	// expression needs are always printed
+	KeepParentheses bool
+
+	// StandardTypeNames prints built-in types that were written with their internal name, e.g. int4, with their
+	// SQL-standard name instead
+	StandardTypeNames bool
+
+	// StandardCasts prints casts as cast(x as t) instead of x::t
+	StandardCasts bool
+
+	// DollarQuoteLength dollar quotes string literals that are longer than this or that span lines, 0 keeps all of
+	// them in single quotes
+	DollarQuoteLength int
+
+	// NormaliseNumbers rewrites numeric constants in a consistent form that keeps their value, e.g. 1.50E+3 as 1.5e3
+	NormaliseNumbers bool
+
+	// ParameterStyle converts all parameters to one placeholder syntax, parameters without a name are named after
+	// their position, e.g. p1. Placeholders in the place of an identifier can only be converted to a named style
+	ParameterStyle ParameterStyle
+
+	// VerbatimFallback copies the source text of the parts of a statement that can not be formatted to the output
+	// instead of failing, see VerbatimRegions
+	VerbatimFallback bool
+
+	// BlankLines is the number of blank lines between statements the author separated with at least one blank
+	// line, statements written without a blank line between them stay together. NewDefaultFormatter keeps
+	// DefaultBlankLines
+	BlankLines int
+
+	// MaxWidth keeps clauses, subqueries and lists that fit in this many columns on one line and breaks the ones
+	// that do not, 0 gives every clause and item a line of its own
+	MaxWidth int
+}
+
+// DefaultBlankLines The blank lines kept between the statements the author separated with blank lines, unless the
+// options say otherwise
+const DefaultBlankLines = 1
+
 type DefaultFormatter struct {
 	printer            interfaces.SqlPrinter
 	detectedParameters map[int]string
-	paramCounter       int
+	parameterNumbers   map[int]int
+	options            FormatterOptions
+	source             string
+	statement          int
+	statementStart     int
+	statementEnd       int
+	verbatim           []VerbatimRegion
+	comments           []comment
+	nextComment        int
+	commentEnd         int
+	pendingComments    []comment
+	layout             bool
 	debug              bool
 }
 
-func NewDefaultFormatterWithParameters(printer interfaces.SqlPrinter, parameterLookup map[int]string) *DefaultFormatter {
+func NewDefaultFormatterWithOptions(printer interfaces.SqlPrinter, parameterLookup map[int]string, options FormatterOptions) *DefaultFormatter {
+	if options.MaxWidth > 0 {
+		printer.SetMaxWidth(options.MaxWidth)
+	}
+
 	return &DefaultFormatter{
 		printer:            printer,
 		detectedParameters: parameterLookup,
+		options:            options,
 		debug:              false,
 	}
 }
 
+func NewDefaultFormatterWithParameters(printer interfaces.SqlPrinter, parameterLookup map[int]string) *DefaultFormatter {
+	return NewDefaultFormatterWithOptions(printer, parameterLookup, FormatterOptions{BlankLines: DefaultBlankLines})
+}
+
 func NewDefaultFormatter(printer interfaces.SqlPrinter) *DefaultFormatter {
 	return NewDefaultFormatterWithParameters(printer, nil)
 }
@@ -35,35 +144,66 @@ func (df *DefaultFormatter) d() {
 	fmt.Println(df.printer)
 }
 
-func (df *DefaultFormatter) p(msg string) {
-	// Dump the printer's content before we panic to aid with debugging
-	fmt.Println(df.printer)
+// p Stops printing the current statement because node can not be printed, PrintNode turns this into an error
+func (df *DefaultFormatter) p(node nodes.Node, msg string) {
+	panic(df.newUnsupportedError(node, msg))
+}
 
-	panic(msg + " not supported")
+// SetSource Provides the sql text the nodes were parsed from, for the few things the parse tree does not record
+func (df *DefaultFormatter) SetSource(sql string) {
+	df.source = sql
+	df.comments = scanComments(sql)
+	df.nextComment = 0
+	df.commentEnd = 0
+	df.pendingComments = nil
+
+	// the parser drops comments, so they are printed along with the nodes they were written next to
+	if _, ok := df.printer.(*commentPrinter); !ok && len(df.comments) > 0 {
+		df.printer = &commentPrinter{SqlPrinter: df.printer, df: df}
+	}
 }
 
 func (df *DefaultFormatter) String() string {
 	return df.printer.String()
 }
 
-func (df *DefaultFormatter) PrintWithClause(wc nodes.WithClause) {
-	if wc.Recursive {
-		df.p("With Clause - Recursive")
+// scratch Creates a formatter with the same settings and state that prints into a throw away printer,
+// which is used to find out what a node will look like before it gets printed
+func (df *DefaultFormatter) scratch() *DefaultFormatter {
+	return &DefaultFormatter{
+		printer:            printers.NewDefaultSpacePrinter(),
+		detectedParameters: df.detectedParameters,
+		parameterNumbers:   df.parameterNumbers,
+		options:            df.options,
+		source:             df.source,
+		statement:          df.statement,
+		statementStart:     df.statementStart,
+		statementEnd:       df.statementEnd,
 	}
+}
+
+func (df *DefaultFormatter) PrintWithClause(wc nodes.WithClause) {
+	df.beginGroup()
 
 	for i := range wc.Ctes.Items {
 		if i == 0 {
-			df.printer.PrintKeyword("with ")
+			df.printer.PrintKeyword("with ", true)
+
+			if wc.Recursive {
+				df.printer.PrintKeyword("recursive ")
+			}
 		}
 
 		df.printNode(wc.Ctes.Items[i], false)
 
 		if i < len(wc.Ctes.Items)-1 {
 			df.printer.PrintString(",")
+			df.lineBreak(" ")
 		}
-
-		df.printer.NewLine()
 	}
+
+	df.endGroup()
+	df.lineBreak(" ")
 }
 
 func (df *DefaultFormatter) PrintSelectStatementTargets(ss nodes.SelectStmt) {
@@ -85,128 +225,926 @@ func (df *DefaultFormatter) PrintSelectStatementTargets(ss nodes.SelectStmt) {
 
 		if i < len(ss.TargetList.Items)-1 {
 			df.printer.PrintString(",")
-			df.printer.NewLine()
+			df.lineBreak(" ")
 		}
 	}
 
 	if ss.IntoClause != nil {
-		df.p("Select - Into clause")
+		df.p(*ss.IntoClause, "Select - Into clause")
 	}
 
 	df.printer.DecIndent()
-
-	// only drop a new line if we are selecting something
-	if len(ss.DistinctClause.Items) > 0 || len(ss.TargetList.Items) > 0 {
-		df.printer.NewLine()
-	}
+	df.endGroup()
 }
 
 func (df *DefaultFormatter) PrintSelectStatementFromClause(ss nodes.SelectStmt) {
-	for i := range ss.FromClause.Items {
-		if je, ok := ss.FromClause.Items[i].(nodes.JoinExpr); ok {
-			df.PrintJoin(i == 0, je)
-		} else {
-			if i == 0 {
-				df.printer.PrintKeyword("from", true)
-				df.printer.IncIndent()
-			}
+	df.PrintFromList("from", ss.FromClause)
+}
 
-			df.printer.NewLine()
-			df.printNode(ss.FromClause.Items[i], true)
+// PrintFromList Prints the tables, sub-selects and joins of a from (or similar) clause under the given keyword
+func (df *DefaultFormatter) PrintFromList(keyword string, items nodes.List) {
+	if len(items.Items) == 0 {
+		return
+	}
 
-			if i < len(ss.FromClause.Items)-1 {
-				df.printer.PrintString(",")
-			}
+	df.lineBreak(" ")
+	df.beginGroup()
+	df.printer.PrintKeyword(keyword, true)
+	df.printer.IncIndent()
+
+	for i, item := range items.Items {
+		df.lineBreak(" ")
+		df.printNode(item, true)
+
+		if i < len(items.Items)-1 {
+			df.printer.PrintString(",")
 		}
 	}
+
+	df.printer.DecIndent()
+	df.endGroup()
 }
 
 func (df *DefaultFormatter) PrintSelectStatementWhereClause(ss nodes.SelectStmt) {
-	df.printer.DecIndent()
-	if ss.WhereClause != nil {
-		df.printer.NewLine()
+	df.PrintWhereClause(ss.WhereClause)
+}
+
+// PrintWhereClause Prints a where clause, shared by all statements that support one
+func (df *DefaultFormatter) PrintWhereClause(where nodes.Node) {
+	if where != nil {
+		df.lineBreak(" ")
+		df.beginGroup()
 		df.printer.PrintKeyword("where", true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.IncIndent()
-		df.printNode(ss.WhereClause, true)
+		df.printNode(where, true)
 		df.printer.DecIndent()
+		df.endGroup()
 	}
 }
 
 func (df *DefaultFormatter) PrintSelectStatementSortClause(ss nodes.SelectStmt) {
 	if len(ss.SortClause.Items) > 0 {
-		df.printer.NewLine()
+		df.lineBreak(" ")
+		df.beginGroup()
 		df.printer.PrintKeyword("order by", true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.IncIndent()
 
 		for i, item := range ss.SortClause.Items {
 			df.printNode(item, true)
 			if i < len(ss.SortClause.Items)-1 {
 				df.printer.PrintString(",")
-				df.printer.NewLine()
+				df.lineBreak(" ")
 			}
 		}
 		df.printer.DecIndent()
+		df.endGroup()
 	}
 }
 
 func (df *DefaultFormatter) PrintSelectStatementLimitClause(ss nodes.SelectStmt) {
+	if ss.LimitCount != nil && df.isFetchFirst(ss.LimitCount) {
+		// the standard syntax requires the offset to come first
+		df.PrintSelectStatementOffsetClause(ss)
+		df.lineBreak(" ")
+
+		// fetch first row only has no count of its own
+		if ac, ok := ss.LimitCount.(nodes.A_Const); ok && ac.Location < 0 {
+			df.printer.PrintKeyword("fetch first row only", true)
+			return
+		}
+
+		df.beginGroup()
+		df.printer.PrintKeyword("fetch first", true)
+		df.lineBreak(" ")
+		df.printer.IncIndent()
+
+		// the count of fetch first can only be an expression in parentheses
+		switch ss.LimitCount.(type) {
+		case nodes.A_Const, nodes.ParamRef, nodes.ColumnRef, nodes.FuncCall:
+			df.printNode(ss.LimitCount, true)
+
+		default:
+			df.printer.PrintString("(", true)
+			df.printNode(ss.LimitCount, false)
+			df.printer.PrintString(")")
+		}
+
+		df.printer.PrintKeyword(" rows only")
+		df.printer.DecIndent()
+		df.endGroup()
+
+		return
+	}
+
 	if ss.LimitCount != nil {
-		df.printer.NewLine()
+		df.lineBreak(" ")
+		df.beginGroup()
 		df.printer.PrintKeyword("limit", true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.IncIndent()
-		df.printNode(ss.LimitCount, true)
+
+		// the parser turns limit all into a null limit
+		if ac, ok := ss.LimitCount.(nodes.A_Const); ok && ac.Val == (nodes.Null{}) {
+			df.printer.PrintKeyword("all", true)
+		} else {
+			df.printNode(ss.LimitCount, true)
+		}
+
 		df.printer.DecIndent()
+		df.endGroup()
+	}
+
+	df.PrintSelectStatementOffsetClause(ss)
+}
+
+func (df *DefaultFormatter) PrintSelectStatementOffsetClause(ss nodes.SelectStmt) {
+	if ss.LimitOffset != nil {
+		df.lineBreak(" ")
+		df.beginGroup()
+		df.printer.PrintKeyword("offset", true)
+		df.lineBreak(" ")
+		df.printer.IncIndent()
+		df.printNode(ss.LimitOffset, true)
+		df.printer.DecIndent()
+		df.endGroup()
+	}
+}
+
+// isFetchFirst The parser stores fetch first and limit the same way, so the source is checked for the keyword
+// that introduced the count: limit, or the first or next of fetch first
+func (df *DefaultFormatter) isFetchFirst(limitCount nodes.Node) bool {
+	// fetch first row only has no count of its own
+	if ac, ok := limitCount.(nodes.A_Const); ok && ac.Location < 0 {
+		return true
+	}
+
+	location, _ := nodeSpan(limitCount)
+	if location < 0 || location > len(df.source) {
+		return false
+	}
+
+	keyword := strings.ToLower(df.tokenBefore(location))
+
+	return keyword == "first" || keyword == "next"
+}
+
+// tokenBefore Returns the token of the statement that ends before location. Opening parentheses are left out,
+// comments are skipped and quoted text counts as a single token
+func (df *DefaultFormatter) tokenBefore(location int) string {
+	previous := ""
+
+	for i := skipSpaceAndComments(df.source, df.statementStart); i < location; i = skipSpaceAndComments(df.source, i) {
+		if df.source[i] == '(' {
+			i++
+			continue
+		}
+
+		end := tokenEnd(df.source, i)
+		if end > location {
+			break
+		}
+
+		previous = df.source[i:end]
+		i = end
+	}
+
+	return previous
+}
+
+func (df *DefaultFormatter) PrintSelectStatementLockingClause(ss nodes.SelectStmt) {
+	for _, item := range ss.LockingClause.Items {
+		if lc, ok := item.(nodes.LockingClause); ok {
+			df.lineBreak(" ")
+			df.PrintLockingClause(lc)
+		}
+	}
+}
+
+func (df *DefaultFormatter) PrintLockingClause(lc nodes.LockingClause) {
+	strength := ""
+
+	switch lc.Strength {
+	case nodes.LCS_FORKEYSHARE:
+		strength = "for key share"
+
+	case nodes.LCS_FORSHARE:
+		strength = "for share"
+
+	case nodes.LCS_FORNOKEYUPDATE:
+		strength = "for no key update"
+
+	case nodes.LCS_FORUPDATE:
+		strength = "for update"
+
+	default:
+		df.p(lc, fmt.Sprintf("Locking Clause Strength - %d", lc.Strength))
+	}
+
+	df.printer.PrintKeyword(strength, true)
+
+	if len(lc.LockedRels.Items) > 0 {
+		df.printer.PrintKeyword(" of ")
+
+		for i, rel := range lc.LockedRels.Items {
+			df.printNode(rel, false)
+
+			if i < len(lc.LockedRels.Items)-1 {
+				df.printer.PrintString(", ")
+			}
+		}
+	}
+
+	switch lc.WaitPolicy {
+	case nodes.LockWaitSkip:
+		df.printer.PrintKeyword(" skip locked")
+
+	case nodes.LockWaitError:
+		df.printer.PrintKeyword(" nowait")
+	}
+}
+
+func (df *DefaultFormatter) PrintSelectStatementGroupByClause(ss nodes.SelectStmt) {
+	if len(ss.GroupClause.Items) > 0 {
+		df.lineBreak(" ")
+		df.beginGroup()
+		df.printer.PrintKeyword("group by", true)
+		df.printer.IncIndent()
+		df.lineBreak(" ")
+
+		for i, item := range ss.GroupClause.Items {
+			df.printNode(item, true)
+			if i < len(ss.GroupClause.Items)-1 {
+				df.printer.PrintString(",")
+				df.lineBreak(" ")
+			}
+		}
+
+		df.printer.DecIndent()
+		df.endGroup()
+	}
+}
+
+func (df *DefaultFormatter) PrintSelectStatementHavingClause(ss nodes.SelectStmt) {
+	if ss.HavingClause != nil {
+		df.lineBreak(" ")
+		df.beginGroup()
+		df.printer.PrintKeyword("having", true)
+		df.printer.IncIndent()
+		df.lineBreak(" ")
+		df.printNode(ss.HavingClause, true)
+		df.printer.DecIndent()
+		df.endGroup()
+	}
+}
+
+func (df *DefaultFormatter) PrintSelectStatementWindowClause(ss nodes.SelectStmt) {
+	if len(ss.WindowClause.Items) > 0 {
+		df.lineBreak(" ")
+		df.beginGroup()
+		df.printer.PrintKeyword("window", true)
+		df.printer.IncIndent()
+		df.lineBreak(" ")
+
+		for i, item := range ss.WindowClause.Items {
+			wd := item.(nodes.WindowDef)
+
+			df.printer.PrintString(*wd.Name, true)
+			df.printer.PrintKeyword(" as ")
+			df.PrintWindowSpecification(wd)
+
+			if i < len(ss.WindowClause.Items)-1 {
+				df.printer.PrintString(",")
+				df.lineBreak(" ")
+			}
+		}
+
+		df.printer.DecIndent()
+		df.endGroup()
+	}
+}
+
+func (df *DefaultFormatter) PrintSelectStatement(ss nodes.SelectStmt) {
+	df.beginGroup()
+
+	if ss.WithClause != nil {
+		df.PrintWithClause(*ss.WithClause)
+	}
+
+	if len(ss.ValuesLists) > 0 {
+		df.PrintValuesLists(ss)
+	} else if ss.Op != nodes.SETOP_NONE {
+		df.PrintSetOperation(ss)
+	} else {
+		// the targets end the group, see PrintSelectStatementTargets
+		df.beginGroup()
+		df.printer.PrintKeyword("select", true)
+		df.printer.IncIndent()
+
+		// only drop a new line if we are selecting something
+		if len(ss.DistinctClause.Items) > 0 || len(ss.TargetList.Items) > 0 {
+			df.lineBreak(" ")
+		}
+
+		df.PrintSelectStatementTargets(ss)
+		df.PrintSelectStatementFromClause(ss)
+		df.PrintSelectStatementWhereClause(ss)
+		df.PrintSelectStatementGroupByClause(ss)
+		df.PrintSelectStatementHavingClause(ss)
+		df.PrintSelectStatementWindowClause(ss)
+	}
+
+	// sorting and limits of a set operation apply to the whole compound statement
+	df.PrintSelectStatementSortClause(ss)
+	df.PrintSelectStatementLimitClause(ss)
+	df.PrintSelectStatementLockingClause(ss)
+	df.endGroup()
+}
+
+// PrintSetOperation Prints the arms of a union, intersect or except with the operator on its own line between them
+func (df *DefaultFormatter) PrintSetOperation(ss nodes.SelectStmt) {
+	rargStart, _ := nodeSpan(*ss.Rarg)
+
+	df.PrintSetOperationArm(*ss.Larg, ss.Op, false, rargStart)
+	df.lineBreak(" ")
+	df.PrintSetOperationType(ss.Op, ss.All)
+	df.lineBreak(" ")
+	df.PrintSetOperationArm(*ss.Rarg, ss.Op, true, -1)
+}
+
+func (df *DefaultFormatter) PrintSetOperationType(op nodes.SetOperation, all bool) {
+	kw := ""
+
+	switch op {
+	case nodes.SETOP_UNION:
+		kw = "union"
+
+	case nodes.SETOP_INTERSECT:
+		kw = "intersect"
+
+	case nodes.SETOP_EXCEPT:
+		kw = "except"
+
+	default:
+		df.p(nil, fmt.Sprintf("Set operation - %v", op))
+	}
+
+	if all {
+		kw += " all"
+	}
+
+	df.printer.PrintKeyword(kw, true)
+}
+
+// PrintSetOperationArm Prints an arm of a set operation, in parentheses when it needs them or was written in them
+//
+// Note: closeBefore is where the arm's sibling on the right starts, -1 if there is none
+func (df *DefaultFormatter) PrintSetOperationArm(arm nodes.SelectStmt, parentOp nodes.SetOperation, right bool, closeBefore int) {
+	if !setOperationArmNeedsParentheses(arm, parentOp, right) && !df.armWrittenInParentheses(arm, closeBefore) {
+		df.PrintSelectStatement(arm)
+		return
+	}
+
+	df.beginGroup()
+	df.printer.PrintString("(", true)
+	df.lineBreak("")
+	df.printer.IncIndent()
+	df.PrintSelectStatement(arm)
+	df.lineBreak("")
+	df.printer.DecIndent()
+	df.printer.PrintString(")", true)
+	df.endGroup()
+}
+
+// setOperationArmNeedsParentheses The parser drops the parentheses around the arms of a set operation, so they
+// are put back wherever leaving them out would change what the statement means
+func setOperationArmNeedsParentheses(arm nodes.SelectStmt, parentOp nodes.SetOperation, right bool) bool {
+	// these clauses would otherwise apply to the whole compound statement
+	if len(arm.SortClause.Items) > 0 ||
+		arm.LimitCount != nil ||
+		arm.LimitOffset != nil ||
+		len(arm.LockingClause.Items) > 0 ||
+		arm.WithClause != nil {
+
+		return true
+	}
+
+	if arm.Op == nodes.SETOP_NONE {
+		return false
+	}
+
+	// set operations are left associative and intersect binds tighter than union and except
+	if right {
+		return setOperationPrecedence(arm.Op) <= setOperationPrecedence(parentOp)
+	}
+
+	return setOperationPrecedence(arm.Op) < setOperationPrecedence(parentOp)
+}
+
+// armKeywords The keywords that can come between the opening parenthesis of a set operation arm and its first token
+var armKeywords = map[string]bool{
+	"select":    true,
+	"distinct":  true,
+	"all":       true,
+	"values":    true,
+	"with":      true,
+	"recursive": true,
+}
+
+// armWrittenInParentheses Checks the source for a pair of parentheses that encloses the arm and nothing else. The
+// first location of an arm is that of its first target or cte, so the keywords in front of it are skipped
+func (df *DefaultFormatter) armWrittenInParentheses(arm nodes.SelectStmt, closeBefore int) bool {
+	first, last := nodeSpan(arm)
+	if first < 0 || first > len(df.source) {
+		return false
+	}
+
+	keyword := false
+
+	for i := first - 1; i >= 0; i-- {
+		switch c := df.source[i]; {
+		case isSpace(c):
+
+		case isWordChar(c):
+			start := i
+			for start > 0 && isWordChar(df.source[start-1]) {
+				start--
+			}
+
+			if !armKeywords[strings.ToLower(df.source[start:i+1])] {
+				return false
+			}
+
+			keyword = true
+			i = start
+
+		case c == '(' && !keyword:
+			// the arm's first target is in parentheses of its own, e.g. select (a)
+
+		case c == '(':
+			close := matchingParenthesis(df.source, i)
+
+			return close > last && (closeBefore < 0 || close < closeBefore)
+
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+func setOperationPrecedence(op nodes.SetOperation) int {
+	if op == nodes.SETOP_INTERSECT {
+		return 2
+	}
+
+	return 1
+}
+
+func (df *DefaultFormatter) PrintInsertStatement(is nodes.InsertStmt) {
+	if is.WithClause != nil {
+		df.PrintWithClause(*is.WithClause)
+	}
+
+	df.beginGroup()
+	df.printer.PrintKeyword("insert into", true)
+	df.lineBreak(" ")
+	df.printer.IncIndent()
+	df.PrintInsertTarget(is)
+	df.printer.DecIndent()
+	df.endGroup()
+
+	df.PrintInsertOverride(is)
+	df.PrintInsertSource(is)
+	df.PrintOnConflictClause(is.OnConflictClause)
+	df.PrintReturningList(is.ReturningList)
+}
+
+func (df *DefaultFormatter) PrintUpdateStatement(us nodes.UpdateStmt) {
+	if us.WithClause != nil {
+		df.PrintWithClause(*us.WithClause)
+	}
+
+	df.beginGroup()
+	df.printer.PrintKeyword("update", true)
+	df.lineBreak(" ")
+	df.printer.IncIndent()
+
+	if us.Relation != nil {
+		df.printNode(*us.Relation, true)
+	}
+
+	df.printer.DecIndent()
+	df.endGroup()
+
+	df.PrintSetClause(us.TargetList)
+	df.PrintFromList("from", us.FromClause)
+	df.PrintWhereClause(us.WhereClause)
+	df.PrintReturningList(us.ReturningList)
+}
+
+func (df *DefaultFormatter) PrintDeleteStatement(ds nodes.DeleteStmt) {
+	if ds.WithClause != nil {
+		df.PrintWithClause(*ds.WithClause)
+	}
+
+	df.beginGroup()
+	df.printer.PrintKeyword("delete from", true)
+	df.lineBreak(" ")
+	df.printer.IncIndent()
+
+	if ds.Relation != nil {
+		df.printNode(*ds.Relation, true)
+	}
+
+	df.printer.DecIndent()
+	df.endGroup()
+
+	df.PrintFromList("using", ds.UsingClause)
+	df.PrintWhereClause(ds.WhereClause)
+	df.PrintReturningList(ds.ReturningList)
+}
+
+func (df *DefaultFormatter) PrintInsertTarget(is nodes.InsertStmt) {
+	if is.Relation != nil {
+		rv := *is.Relation
+		rv.Alias = nil
+		df.PrintRangeVar(rv, true)
+
+		// the alias of an insert target requires the as keyword
+		if is.Relation.Alias != nil {
+			df.printer.PrintKeyword(" as ")
+			df.PrintAlias(*is.Relation.Alias)
+		}
+	}
+
+	if len(is.Cols.Items) > 0 {
+		df.printer.PrintString(" (")
+
+		for i, col := range is.Cols.Items {
+			df.PrintColumnTarget(col.(nodes.ResTarget))
+
+			if i < len(is.Cols.Items)-1 {
+				df.printer.PrintString(", ")
+			}
+		}
+
+		df.printer.PrintString(")")
+	}
+}
+
+func (df *DefaultFormatter) PrintInsertOverride(is nodes.InsertStmt) {
+	switch is.Override {
+	case nodes.OVERRIDING_USER_VALUE:
+		df.lineBreak(" ")
+		df.printer.PrintKeyword("overriding user value", true)
+
+	case nodes.OVERRIDING_SYSTEM_VALUE:
+		df.lineBreak(" ")
+		df.printer.PrintKeyword("overriding system value", true)
+	}
+}
+
+func (df *DefaultFormatter) PrintInsertSource(is nodes.InsertStmt) {
+	df.lineBreak(" ")
+
+	if is.SelectStmt == nil {
+		df.printer.PrintKeyword("default values", true)
+		return
+	}
+
+	df.printNode(is.SelectStmt, true)
+}
+
+// PrintValuesLists Prints a values list with one row per line
+func (df *DefaultFormatter) PrintValuesLists(ss nodes.SelectStmt) {
+	var cellWidths [][]int
+	var columnWidths []int
+
+	if df.options.AlignValues {
+		cellWidths, columnWidths = df.valuesListsWidths(ss)
+	}
+
+	df.beginGroup()
+	df.printer.PrintKeyword("values", true)
+	df.printer.IncIndent()
+
+	for i, row := range ss.ValuesLists {
+		// aligned rows only line up on lines of their own
+		if columnWidths != nil {
+			df.printer.NewLine()
+		} else {
+			df.lineBreak(" ")
+		}
+
+		df.printer.PrintString("(", true)
+
+		for j, val := range row {
+			df.printNode(val, false)
+
+			if j < len(row)-1 {
+				df.printer.PrintString(",")
+
+				if columnWidths != nil {
+					df.printer.PrintString(strings.Repeat(" ", columnWidths[j]-cellWidths[i][j]))
+				}
+
+				df.printer.PrintString(" ")
+			}
+		}
+
+		df.printer.PrintString(")")
+
+		if i < len(ss.ValuesLists)-1 {
+			df.printer.PrintString(",")
+		}
+	}
+
+	df.printer.DecIndent()
+	df.endGroup()
+}
+
+// valuesListsWidths Measures every cell of a values list and the widest cell of every column.
+// Nothing is returned when the rows can't be aligned, i.e. when they differ in length or a cell spans multiple lines
+func (df *DefaultFormatter) valuesListsWidths(ss nodes.SelectStmt) ([][]int, []int) {
+	cellWidths := make([][]int, len(ss.ValuesLists))
+	columnWidths := make([]int, len(ss.ValuesLists[0]))
+	scratch := df.scratch()
+
+	for i, row := range ss.ValuesLists {
+		if len(row) != len(columnWidths) {
+			return nil, nil
+		}
+
+		cellWidths[i] = make([]int, len(row))
+
+		for j, val := range row {
+			// a fresh printer per cell to measure it on its own
+			scratch.printer = printers.NewDefaultSpacePrinter()
+			scratch.printNode(val, false)
+
+			cell := scratch.String()
+			if strings.Contains(cell, "\n") {
+				return nil, nil
+			}
+
+			cellWidths[i][j] = utf8.RuneCountInString(cell)
+			if cellWidths[i][j] > columnWidths[j] {
+				columnWidths[j] = cellWidths[i][j]
+			}
+		}
+	}
+
+	return cellWidths, columnWidths
+}
+
+func (df *DefaultFormatter) PrintOnConflictClause(occ *nodes.OnConflictClause) {
+	if occ == nil {
+		return
+	}
+
+	df.lineBreak(" ")
+	df.printer.PrintKeyword("on conflict", true)
+
+	if occ.Infer != nil {
+		df.PrintInferClause(*occ.Infer)
+	}
+
+	switch occ.Action {
+	case nodes.ONCONFLICT_NOTHING:
+		df.printer.PrintKeyword(" do nothing")
+
+	case nodes.ONCONFLICT_UPDATE:
+		df.printer.PrintKeyword(" do update")
+		df.PrintSetClause(occ.TargetList)
+		df.PrintWhereClause(occ.WhereClause)
+
+	default:
+		df.p(*occ, fmt.Sprintf("On Conflict - action %v", occ.Action))
+	}
+}
+
+func (df *DefaultFormatter) PrintInferClause(ic nodes.InferClause) {
+	if ic.Conname != nil {
+		df.printer.PrintKeyword(" on constraint ")
+		df.printer.PrintString(*ic.Conname)
+		return
+	}
+
+	df.printer.PrintString(" (")
+
+	for i, elem := range ic.IndexElems.Items {
+		df.printNode(elem, false)
+
+		if i < len(ic.IndexElems.Items)-1 {
+			df.printer.PrintString(", ")
+		}
+	}
+
+	df.printer.PrintString(")")
+
+	if ic.WhereClause != nil {
+		df.printer.PrintKeyword(" where ")
+		df.printNode(ic.WhereClause, false)
+	}
+}
+
+func (df *DefaultFormatter) PrintIndexElem(ie nodes.IndexElem, withIndent bool) {
+	if ie.Name != nil {
+		df.printer.PrintString(*ie.Name, withIndent)
+	} else if _, ok := ie.Expr.(nodes.FuncCall); ok {
+		df.printNode(ie.Expr, withIndent)
+	} else {
+		// arbitrary expressions have to be wrapped in parentheses
+		df.printer.PrintString("(", withIndent)
+		df.printNode(ie.Expr, false)
+		df.printer.PrintString(")")
+	}
+
+	if len(ie.Collation.Items) > 0 {
+		df.printer.PrintKeyword(" collate ")
+		df.printNames(ie.Collation)
+	}
+
+	if len(ie.Opclass.Items) > 0 {
+		df.printer.PrintString(" ")
+		df.printNames(ie.Opclass)
+	}
+
+	switch ie.Ordering {
+	case nodes.SORTBY_ASC:
+		df.printer.PrintKeyword(" asc")
+
+	case nodes.SORTBY_DESC:
+		df.printer.PrintKeyword(" desc")
+	}
+
+	switch ie.NullsOrdering {
+	case nodes.SORTBY_NULLS_FIRST:
+		df.printer.PrintKeyword(" nulls first")
+
+	case nodes.SORTBY_NULLS_LAST:
+		df.printer.PrintKeyword(" nulls last")
+	}
+}
+
+// PrintSetClause Prints the assignments of an update or an on conflict do update, one per line
+func (df *DefaultFormatter) PrintSetClause(targets nodes.List) {
+	df.lineBreak(" ")
+	df.beginGroup()
+	df.printer.PrintKeyword("set", true)
+	df.printer.IncIndent()
+
+	for i := 0; i < len(targets.Items); i++ {
+		rt := targets.Items[i].(nodes.ResTarget)
+
+		df.lineBreak(" ")
+		df.printer.PrintString("", true)
+
+		if mar, ok := rt.Val.(nodes.MultiAssignRef); ok {
+			// (a, b) = (...) is split into one target per column that all share the same source
+			df.printer.PrintString("(")
+
+			for j := 0; j < mar.Ncolumns; j++ {
+				df.PrintColumnTarget(targets.Items[i+j].(nodes.ResTarget))
+
+				if j < mar.Ncolumns-1 {
+					df.printer.PrintString(", ")
+				}
+			}
+
+			df.printer.PrintString(") = ")
+			df.printNode(mar.Source, false)
+
+			i += mar.Ncolumns - 1
+		} else {
+			df.PrintColumnTarget(rt)
+			df.printer.PrintString(" = ")
+			df.printNode(rt.Val, false)
+		}
+
+		if i < len(targets.Items)-1 {
+			df.printer.PrintString(",")
+		}
+	}
+
+	df.printer.DecIndent()
+	df.endGroup()
+}
+
+// PrintColumnTarget Prints the column a ResTarget assigns to, including any subscripts or field selections
+func (df *DefaultFormatter) PrintColumnTarget(rt nodes.ResTarget) {
+	// column targets are printed without printNode, so the comments after them are queued here
+	df.commentsReached(rt.Location, rt.Location+1)
+
+	if rt.Name != nil {
+		df.printer.PrintString(*rt.Name)
+	}
+
+	for _, ind := range rt.Indirection.Items {
+		if _, ok := ind.(nodes.String); ok {
+			df.printer.PrintString(".")
+		}
+
+		df.printNode(ind, false)
+	}
+}
+
+// PrintReturningList Prints the returning clause of an insert, update or delete
+func (df *DefaultFormatter) PrintReturningList(rl nodes.List) {
+	if len(rl.Items) == 0 {
+		return
+	}
+
+	df.lineBreak(" ")
+	df.beginGroup()
+	df.printer.PrintKeyword("returning", true)
+	df.lineBreak(" ")
+	df.printer.IncIndent()
+
+	for i, item := range rl.Items {
+		df.printNode(item, true)
+
+		if i < len(rl.Items)-1 {
+			df.printer.PrintString(",")
+			df.lineBreak(" ")
+		}
+	}
+
+	df.printer.DecIndent()
+	df.endGroup()
+}
+
+func (df *DefaultFormatter) PrintAIndices(ai nodes.A_Indices) {
+	df.printer.PrintString("[")
+
+	if ai.Lidx != nil {
+		df.printNode(ai.Lidx, false)
+	}
+
+	if ai.IsSlice {
+		df.printer.PrintString(":")
+	}
+
+	if ai.Uidx != nil {
+		df.printNode(ai.Uidx, false)
+	}
+
+	df.printer.PrintString("]")
+}
+
+func (df *DefaultFormatter) PrintAIndirection(ai nodes.A_Indirection, withIndent bool) {
+	switch ai.Arg.(type) {
+	case nodes.ColumnRef, nodes.ParamRef:
+		df.printNode(ai.Arg, withIndent)
+
+	default:
+		// anything other than a column or parameter has to be wrapped before it can be subscripted
+		df.printer.PrintString("(", withIndent)
+		df.printNode(ai.Arg, false)
+		df.printer.PrintString(")")
 	}
-}
-
-func (df *DefaultFormatter) PrintSelectStatementGroupByClause(ss nodes.SelectStmt) {
-	if len(ss.GroupClause.Items) > 0 {
-		df.printer.NewLine()
-		df.printer.PrintKeyword("group by", true)
-		df.printer.IncIndent()
-		df.printer.NewLine()
 
-		for i, item := range ss.GroupClause.Items {
-			df.printNode(item, true)
-			if i < len(ss.GroupClause.Items)-1 {
-				df.printer.PrintString(",")
-				df.printer.NewLine()
-			}
+	for _, ind := range ai.Indirection.Items {
+		switch ind.(type) {
+		case nodes.String, nodes.A_Star:
+			df.printer.PrintString(".")
 		}
 
-		df.printer.DecIndent()
+		df.printNode(ind, false)
 	}
 }
 
-func (df *DefaultFormatter) PrintSelectStatementHavingClause(ss nodes.SelectStmt) {
-	if ss.HavingClause != nil {
-		df.printer.NewLine()
-		df.printer.PrintKeyword("having", true)
-		df.printer.IncIndent()
-		df.printer.NewLine()
-		df.printNode(ss.HavingClause, true)
+func (df *DefaultFormatter) PrintRowExpr(re nodes.RowExpr, withIndent bool) {
+	if re.RowFormat == nodes.COERCE_EXPLICIT_CALL {
+		df.printer.PrintKeyword("row", withIndent)
+		df.printer.PrintString("(")
+	} else {
+		df.printer.PrintString("(", withIndent)
 	}
-}
 
-func (df *DefaultFormatter) PrintSelectStatement(ss nodes.SelectStmt) {
-	if ss.WithClause != nil {
-		df.PrintWithClause(*ss.WithClause)
+	for i, arg := range re.Args.Items {
+		df.printNode(arg, false)
+
+		if i < len(re.Args.Items)-1 {
+			df.printer.PrintString(", ")
+		}
 	}
 
-	df.printer.PrintKeyword("select", true)
-	df.printer.NewLine()
-	df.printer.IncIndent()
+	df.printer.PrintString(")")
+}
 
-	df.PrintSelectStatementTargets(ss)
-	df.PrintSelectStatementFromClause(ss)
-	df.PrintSelectStatementWhereClause(ss)
-	df.PrintSelectStatementGroupByClause(ss)
-	df.PrintSelectStatementHavingClause(ss)
-	df.PrintSelectStatementSortClause(ss)
-	df.PrintSelectStatementLimitClause(ss)
+// printNames Prints a qualified name, e.g. a collation or operator class
+func (df *DefaultFormatter) printNames(names nodes.List) {
+	for i, name := range names.Items {
+		df.printNode(name, false)
+
+		if i < len(names.Items)-1 {
+			df.printer.PrintString(".")
+		}
+	}
 }
 
 func (df *DefaultFormatter) PrintResTarget(nt nodes.ResTarget, withIndent bool) {
@@ -224,7 +1162,13 @@ func (df *DefaultFormatter) PrintResTarget(nt nodes.ResTarget, withIndent bool)
 
 	if len(retVal) > 0 {
 		df.printer.PrintKeyword(" as ")
-		df.printer.PrintString("\"" + retVal + "\"")
+
+		// a placeholder has to be printed unquoted
+		if helpers.IsPlaceholderIdentifier(retVal) {
+			df.printer.PrintString(df.convertIdentifierParameter(retVal))
+		} else {
+			df.printer.PrintString("\"" + retVal + "\"")
+		}
 	}
 }
 
@@ -238,63 +1182,109 @@ func (df *DefaultFormatter) PrintColumnRef(cr nodes.ColumnRef, withIndent bool)
 	}
 }
 
-func (df *DefaultFormatter) PrintJoin(first bool, join nodes.JoinExpr) {
-	if first {
-		df.printer.PrintKeyword("from", true)
-		df.printer.NewLine()
-		df.printer.IncIndent()
-	}
-
-	if join.IsNatural {
-		df.p("Join - Natural")
+func (df *DefaultFormatter) PrintJoin(join nodes.JoinExpr) {
+	// an aliased join is only valid in parentheses
+	if join.Alias != nil {
+		df.PrintNestedJoin(join)
+		return
 	}
 
 	// cross join
-	if join.Jointype == nodes.JOIN_INNER && join.Quals == nil {
+	if join.Jointype == nodes.JOIN_INNER && join.Quals == nil && !join.IsNatural && len(join.UsingClause.Items) == 0 {
 		df.printNode(join.Larg, true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.DecIndent()
+		df.beginGroup()
 		df.printer.PrintKeyword("cross join", true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.IncIndent()
-		df.printNode(join.Rarg, true)
+		df.printJoinRarg(join.Rarg)
+		df.endGroup()
 	} else {
 		df.printNode(join.Larg, true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.DecIndent()
-		df.PrintJoinType(join.Jointype, true)
-
-		newLine := true
+		df.beginGroup()
 
-		switch join.Rarg.(type) {
-		case nodes.RangeSubselect:
-			newLine = false
+		if join.IsNatural {
+			df.printer.PrintKeyword("natural ", true)
+			df.PrintJoinType(join.Jointype, false)
+		} else {
+			df.PrintJoinType(join.Jointype, true)
 		}
 
-		// Sub-select's manage their own newlines, due to lateral joins
-		if newLine {
-			df.printer.NewLine()
-			df.printer.IncIndent()
+		rarg := join.Rarg
+
+		// lateral belongs to the join keyword line, so the sub-select must not print it again
+		if rs, ok := rarg.(nodes.RangeSubselect); ok && rs.Lateral {
+			df.printer.PrintKeyword(" lateral")
+			rs.Lateral = false
+			rarg = rs
 		}
 
-		df.printNode(join.Rarg, true)
+		df.lineBreak(" ")
+		df.printer.IncIndent()
+		df.printJoinRarg(rarg)
 
 		if len(join.UsingClause.Items) > 0 {
-			df.p("Join - Using Clause")
+			df.lineBreak(" ")
+			df.beginGroup()
+			df.printer.PrintKeyword("using", true)
+			df.lineBreak(" ")
+			df.printer.IncIndent()
+			df.printer.PrintString("", true)
+			df.PrintAlias(nodes.Alias{Colnames: join.UsingClause}) // prints (a, b) without a name
+			df.printer.DecIndent()
+			df.endGroup()
+		} else if join.Quals != nil {
+			df.lineBreak(" ")
+			df.beginGroup()
+			df.printer.PrintKeyword("on", true)
+			df.lineBreak(" ")
+			df.printer.IncIndent()
+			df.printNode(join.Quals, true)
+			df.printer.DecIndent()
+			df.endGroup()
 		}
 
-		df.printer.NewLine()
-		df.printer.PrintKeyword("on", true)
-		df.printer.NewLine()
-		df.printer.IncIndent()
-		df.printNode(join.Quals, true)
-		df.printer.DecIndent()
+		df.endGroup()
+	}
+}
+
+// printJoinRarg Prints the right hand side of a join, a join on the right hand side always needs parentheses
+func (df *DefaultFormatter) printJoinRarg(rarg nodes.Node) {
+	if join, ok := rarg.(nodes.JoinExpr); ok {
+		df.PrintNestedJoin(join)
+		return
+	}
+
+	df.printNode(rarg, true)
+}
+
+// PrintNestedJoin Prints a join in parentheses, followed by its alias if it has one
+func (df *DefaultFormatter) PrintNestedJoin(join nodes.JoinExpr) {
+	alias := join.Alias
+	join.Alias = nil
+
+	df.beginGroup()
+	df.printer.PrintString("(", true)
+	df.printer.IncIndent()
+	df.lineBreak("")
+	df.PrintJoin(join)
+	df.lineBreak("")
+	df.printer.DecIndent()
+	df.printer.PrintString(")", true)
+	df.endGroup()
+
+	if alias != nil {
+		df.printer.PrintString(" ")
+		df.PrintAlias(*alias)
 	}
 }
 
 func (df *DefaultFormatter) PrintAlias(alias nodes.Alias) {
 	if alias.Aliasname != nil {
-		df.printer.PrintString(*(alias.Aliasname))
+		df.printer.PrintString(df.convertIdentifierParameter(*alias.Aliasname))
 	}
 
 	if len(alias.Colnames.Items) > 0 {
@@ -328,7 +1318,7 @@ func (df *DefaultFormatter) PrintJoinType(joinType nodes.JoinType, withIndent bo
 		jt = "right join"
 
 	default:
-		df.p(fmt.Sprintf("join type - %+v not supported", joinType))
+		df.p(nil, fmt.Sprintf("Join type - %+v", joinType))
 	}
 
 	if len(jt) > 0 {
@@ -339,8 +1329,14 @@ func (df *DefaultFormatter) PrintJoinType(joinType nodes.JoinType, withIndent bo
 func (df *DefaultFormatter) PrintRangeVar(rv nodes.RangeVar, withIndent bool) {
 	name := ""
 
+	// inheritance is on by default, it is only switched off by only
+	if !rv.Inh {
+		df.printer.PrintKeyword("only ", withIndent)
+		withIndent = false
+	}
+
 	if rv.Catalogname != nil {
-		name = *rv.Catalogname
+		name = df.convertIdentifierParameter(*rv.Catalogname)
 	}
 
 	if rv.Schemaname != nil {
@@ -348,7 +1344,7 @@ func (df *DefaultFormatter) PrintRangeVar(rv nodes.RangeVar, withIndent bool) {
 			name += "."
 		}
 
-		name += *rv.Schemaname
+		name += df.convertIdentifierParameter(*rv.Schemaname)
 	}
 
 	if rv.Relname != nil {
@@ -356,7 +1352,7 @@ func (df *DefaultFormatter) PrintRangeVar(rv nodes.RangeVar, withIndent bool) {
 			name += "."
 		}
 
-		name += *rv.Relname
+		name += df.convertIdentifierParameter(*rv.Relname)
 	}
 
 	df.printer.PrintString(name, withIndent)
@@ -377,10 +1373,12 @@ func (df *DefaultFormatter) PrintBoolExpr(be nodes.BoolExpr, prevOp nodes.BoolEx
 	// Drop parentheses when change operators
 	parentheses := be.Boolop != prevOp && be.Boolop != 2
 
+	df.beginGroup()
+
 	if parentheses {
 		df.printer.PrintString("(", withIndent)
 
-		df.printer.NewLine()
+		df.lineBreak("")
 		df.printer.IncIndent()
 	}
 
@@ -389,16 +1387,32 @@ func (df *DefaultFormatter) PrintBoolExpr(be nodes.BoolExpr, prevOp nodes.BoolEx
 			df.PrintBoolExprType(be.Boolop, withIndent)
 		}
 
-		if tbe, ok := be.Args.Items[i].(nodes.BoolExpr); ok {
-			df.PrintBoolExpr(tbe, be.Boolop, withIndent || i == 0)
+		// the first item starts a fresh line when it follows an opening parenthesis
+		firstIndent := i == 0 && (withIndent || parentheses)
+
+		closeBefore := -1
+		if i < len(be.Args.Items)-1 {
+			closeBefore, _ = nodeSpan(be.Args.Items[i+1])
+		}
+
+		// the parser only merges an and / or chain on its left, so a nested one of the same kind was written in
+		// parentheses
+		tbe, ok := be.Args.Items[i].(nodes.BoolExpr)
+		nested := ok && tbe.Boolop == be.Boolop && int(be.Boolop) != 2
+		kept := ok && df.options.KeepParentheses && df.writtenInParentheses(tbe, closeBefore)
+
+		if ok && !nested && !kept {
+			df.PrintBoolExpr(tbe, be.Boolop, withIndent || firstIndent)
+		} else if ok && int(tbe.Boolop) != 2 {
+			df.PrintBoolExpr(tbe, 2, withIndent || firstIndent)
 		} else {
 			// only print an indent if this is the first item and the operator is not a not
-			df.printNode(be.Args.Items[i], i == 0 && (int(be.Boolop) != 2))
+			df.printOperand(be.Args.Items[i], expressionPrecedence(be), false, closeBefore, firstIndent && (int(be.Boolop) != 2))
 		}
 
 		if i < len(be.Args.Items)-1 {
-			df.printer.NewLine()
-			df.PrintBoolExprType(be.Boolop, true)
+			df.lineBreak(" ")
+			df.PrintBoolExprType(be.Boolop, df.printCommentsBeforeKeyword(closeBefore, true))
 		}
 
 		// Stop printing consecutive args with indent to stop crap like AND<space>tab.col...
@@ -406,10 +1420,12 @@ func (df *DefaultFormatter) PrintBoolExpr(be nodes.BoolExpr, prevOp nodes.BoolEx
 	}
 
 	if parentheses {
-		df.printer.NewLine()
+		df.lineBreak("")
 		df.printer.DecIndent()
 		df.printer.PrintString(")", true)
 	}
+
+	df.endGroup()
 }
 
 func (df *DefaultFormatter) PrintBoolExprType(exprType nodes.BoolExprType, withIndent bool) {
@@ -433,8 +1449,10 @@ func (df *DefaultFormatter) PrintBoolExprType(exprType nodes.BoolExprType, withI
 }
 
 func (df *DefaultFormatter) PrintAExpr(ae nodes.A_Expr, withIndent bool) {
+	precedence := aExprPrecedence(ae)
+
 	if ae.Lexpr != nil {
-		df.printNode(ae.Lexpr, withIndent)
+		df.printOperand(ae.Lexpr, precedence, false, ae.Location, withIndent)
 	}
 
 	switch ae.Kind {
@@ -455,32 +1473,101 @@ func (df *DefaultFormatter) PrintAExpr(ae nodes.A_Expr, withIndent bool) {
 	case nodes.AEXPR_BETWEEN,
 		nodes.AEXPR_NOT_BETWEEN:
 
+		// the bounds can not contain anything that binds looser than between
 		df.PrintAExprKeywords(ae.Name, true)
-		df.printNode(ae.Rexpr.(nodes.List).Items[0], false)
+		df.printOperand(ae.Rexpr.(nodes.List).Items[0], precedence, true, -1, false)
 		df.printer.PrintKeyword(" and ")
-		df.printNode(ae.Rexpr.(nodes.List).Items[1], false)
+		df.printOperand(ae.Rexpr.(nodes.List).Items[1], precedence, true, -1, false)
 
 	case nodes.AEXPR_LIKE:
-		df.printer.PrintKeyword(" like ")
-		df.printNode(ae.Rexpr, false)
+		// not like is the !~~ operator
+		if operatorName(ae.Name) == "!~~" {
+			df.printer.PrintKeyword(" not like ")
+		} else {
+			df.printer.PrintKeyword(" like ")
+		}
+
+		df.printOperand(ae.Rexpr, precedence, true, -1, false)
 
 	case nodes.AEXPR_ILIKE:
-		df.printer.PrintKeyword(" ilike ")
-		df.printNode(ae.Rexpr, false)
+		// not ilike is the !~~* operator
+		if operatorName(ae.Name) == "!~~*" {
+			df.printer.PrintKeyword(" not ilike ")
+		} else {
+			df.printer.PrintKeyword(" ilike ")
+		}
+
+		df.printOperand(ae.Rexpr, precedence, true, -1, false)
 
 	case nodes.AEXPR_OP:
 		if ae.Lexpr == nil {
 			// we need an indent, that Lexpr gives
-			df.printer.PrintString("", true)
+			df.printer.PrintString("", withIndent)
+		}
+		// Prints the operator, after the comments that were written in front of it
+		if ae.Lexpr != nil && df.blockCommentBefore(ae.Location) {
+			df.printer.PrintString(" ")
+			df.printLeadingComments(ae.Location, false)
+			df.PrintAExprKeywords(ae.Name, false)
+			df.printer.PrintString(" ")
+		} else {
+			df.PrintAExprKeywords(ae.Name, ae.Lexpr != nil)
 		}
-		// Prints the operator
-		df.PrintAExprKeywords(ae.Name, ae.Lexpr != nil)
 
-		df.printNode(ae.Rexpr, false)
+		// a prefix operator glued to an operand that starts with an operator character reads as a different operator
+		if ae.Lexpr == nil && startsWithOperator(ae.Rexpr) && !df.parenthesiseOperand(ae.Rexpr, precedence, true, -1) {
+			df.printer.PrintString(" ")
+		}
+
+		df.printOperand(ae.Rexpr, precedence, true, -1, false)
 
 	default:
-		df.p(fmt.Sprintf("AExpr: %v", ae.Kind))
+		df.p(ae, fmt.Sprintf("Kind %v", ae.Kind))
+	}
+}
+
+// printOperand Prints an operand of an operator, with parentheses when the operator would otherwise bind to only
+// a part of it or when it is a redundant pair the author wrote and that is being kept
+//
+// Note: closeBefore is where the operand's siblings on the right start, -1 if there are none
+func (df *DefaultFormatter) printOperand(operand nodes.Node, precedence int, right bool, closeBefore int, withIndent bool) {
+	if !df.parenthesiseOperand(operand, precedence, right, closeBefore) {
+		df.printNode(operand, withIndent)
+		return
+	}
+
+	// and / or print their own parentheses when they differ from the enclosing operator
+	if be, ok := operand.(nodes.BoolExpr); ok && int(be.Boolop) != 2 {
+		df.PrintBoolExpr(be, 2, withIndent)
+		return
+	}
+
+	df.printer.PrintString("(", withIndent)
+	df.printNode(operand, false)
+	df.printer.PrintString(")")
+}
+
+// parenthesiseOperand Checks whether printOperand prints the operand in parentheses
+func (df *DefaultFormatter) parenthesiseOperand(operand nodes.Node, precedence int, right bool, closeBefore int) bool {
+	return operandNeedsParentheses(operand, precedence, right) ||
+		(df.options.KeepParentheses && df.writtenInParentheses(operand, closeBefore))
+}
+
+// writtenInParentheses Checks the source for a pair of parentheses that encloses the node and nothing else
+func (df *DefaultFormatter) writtenInParentheses(node nodes.Node, closeBefore int) bool {
+	first, last := nodeSpan(node)
+	if first < 0 || first > len(df.source) {
+		return false
+	}
+
+	open := len(strings.TrimRight(df.source[:first], " \t\r\n")) - 1
+	if open < 0 || df.source[open] != '(' {
+		return false
 	}
+
+	close := matchingParenthesis(df.source, open)
+
+	return close > last && (closeBefore < 0 || close < closeBefore)
 }
 
 func (df *DefaultFormatter) PrintAExprKeywords(op nodes.List, spaces bool) {
@@ -503,7 +1590,7 @@ func (df *DefaultFormatter) PrintNullTest(nt nodes.NullTest, withIndent bool) {
 		df.printNode(nt.Xpr, withIndent)
 	}
 
-	df.printNode(nt.Arg, withIndent)
+	df.printOperand(nt.Arg, precedenceIs, false, nt.Location, withIndent)
 
 	if nt.Nulltesttype == nodes.IS_NULL {
 		df.printer.PrintKeyword(" is null")
@@ -516,9 +1603,11 @@ func (df *DefaultFormatter) PrintAConst(ac nodes.A_Const, withindent bool) {
 	switch ac.Val.(type) {
 	case nodes.String:
 
-		df.printer.PrintString("'", withindent)
-		df.printNode(ac.Val, false)
-		df.printer.PrintString("'")
+		df.PrintStringLiteral(ac.Val.(nodes.String).Str, ac.Location, withindent)
+
+	case nodes.BitString:
+
+		df.printer.PrintString(formatBitString(ac.Val.(nodes.BitString).Str), withindent)
 
 	default:
 
@@ -526,111 +1615,370 @@ func (df *DefaultFormatter) PrintAConst(ac nodes.A_Const, withindent bool) {
 	}
 }
 
+// PrintFloat Prints a numeric constant from the text it was written as, converting it to a float would lose precision
+func (df *DefaultFormatter) PrintFloat(f nodes.Float, withIndent bool) {
+	if df.options.NormaliseNumbers {
+		df.printer.PrintString(helpers.NormaliseNumber(f.Str), withIndent)
+		return
+	}
+
+	df.printer.PrintString(f.Str, withIndent)
+}
+
+// PrintStringLiteral Prints a string constant quoted so that it reads back as the same value
+func (df *DefaultFormatter) PrintStringLiteral(value string, location int, withIndent bool) {
+	if df.options.DollarQuoteLength > 0 &&
+		(len(value) > df.options.DollarQuoteLength || strings.Contains(value, "\n")) {
+
+		df.printer.PrintString(quoteDollarString(value), withIndent)
+		return
+	}
+
+	if df.writtenAsEscapeString(location) || containsControlCharacters(value) {
+		df.printer.PrintString(quoteEscapeString(value), withIndent)
+		return
+	}
+
+	df.printer.PrintString(quoteString(value), withIndent)
+}
+
+// writtenAsEscapeString Checks the source for an escape string, e.g. E'\n', at the constant's location
+func (df *DefaultFormatter) writtenAsEscapeString(location int) bool {
+	if location < 0 || location+1 >= len(df.source) {
+		return false
+	}
+
+	return (df.source[location] == 'e' || df.source[location] == 'E') && df.source[location+1] == '\''
+}
+
 func (df *DefaultFormatter) PrintCommonTableExpr(cte nodes.CommonTableExpr) {
+	df.beginGroup()
+
 	if cte.Ctename != nil {
 		df.printer.PrintString(*cte.Ctename)
+
+		// the column names of a cte are printed like those of a table alias
+		if len(cte.Aliascolnames.Items) > 0 {
+			df.PrintAlias(nodes.Alias{Colnames: cte.Aliascolnames})
+		}
+
 		df.printer.PrintKeyword(" as ")
 		df.printer.PrintString("(")
-		df.printer.NewLine()
+		df.lineBreak("")
 		df.printer.IncIndent()
 	}
 
-	if cte.Cterecursive {
-		df.p("CTE - Recursive")
-	}
-
 	df.printNode(cte.Ctequery, false)
 
-	df.printer.NewLine()
+	df.lineBreak("")
 	df.printer.DecIndent()
 	df.printer.PrintString(")", true)
+	df.endGroup()
 }
 
 func (df *DefaultFormatter) PrintParamRef(pr nodes.ParamRef, withIndent bool) {
-	if df.detectedParameters != nil {
-		if param, ok := df.detectedParameters[df.paramCounter]; ok {
-			df.printer.PrintString(param, withIndent)
-			df.paramCounter++
-			return
+	df.printer.PrintString(df.convertParameter(pr), withIndent)
+}
+
+// writtenParameter Returns the placeholder a parameter was written as
+func (df *DefaultFormatter) writtenParameter(pr nodes.ParamRef) string {
+	if pr.Number > 0 {
+		return fmt.Sprintf("$%d", pr.Number)
+	}
+
+	// the named parameters were replaced by bare ones before parsing, keyed by where they were found
+	if param, ok := df.detectedParameters[pr.Location]; ok {
+		return param
+	}
+
+	return "?"
+}
+
+// convertParameter Rewrites a placeholder in the configured parameter style
+func (df *DefaultFormatter) convertParameter(pr nodes.ParamRef) string {
+	switch df.options.ParameterStyle {
+	case ParameterStylePositional:
+		return fmt.Sprintf("$%d", df.parameterNumbers[pr.Location])
+
+	case ParameterStyleBare:
+		return "?"
+
+	case ParameterStyleNamed:
+		return "?" + df.parameterName(pr)
+
+	case ParameterStyleColon:
+		return ":" + df.parameterName(pr)
+	}
+
+	return df.writtenParameter(pr)
+}
+
+// numberParameters Gives every parameter of a statement its position, see helpers.NumberParameters
+func (df *DefaultFormatter) numberParameters(stmt nodes.Node) {
+	refs := paramRefs(stmt)
+	placeholders := make([]string, len(refs))
+
+	for i, pr := range refs {
+		placeholders[i] = df.writtenParameter(pr)
+	}
+
+	df.parameterNumbers = make(map[int]int)
+
+	for i, number := range helpers.NumberParameters(placeholders) {
+		df.parameterNumbers[refs[i].Location] = number
+
+		// bare parameters are bound by the order they appear in, so they can not be reordered or used twice
+		if df.options.ParameterStyle == ParameterStyleBare && number != i+1 {
+			df.p(refs[i], fmt.Sprintf("converting %s out of order or more than once to ?", placeholders[i]))
 		}
 	}
+}
+
+func (df *DefaultFormatter) parameterName(pr nodes.ParamRef) string {
+	param := df.writtenParameter(pr)
+
+	if _, err := strconv.Atoi(param[1:]); err != nil && len(param) > 1 && !strings.HasPrefix(param, "$") {
+		return param[1:]
+	}
+
+	return fmt.Sprintf("p%d", df.parameterNumbers[pr.Location])
+}
+
+// convertIdentifierParameter Rewrites a placeholder that stands in for an identifier in the configured parameter
+// style, only named parameters can take the place of an identifier
+func (df *DefaultFormatter) convertIdentifierParameter(identifier string) string {
+	if len(identifier) < 2 || !helpers.IsPlaceholderIdentifier(identifier) {
+		return identifier
+	}
+
+	name := helpers.PlaceholderName(identifier)
+
+	switch df.options.ParameterStyle {
+	case ParameterStylePositional,
+		ParameterStyleBare:
+
+		df.p(nil, fmt.Sprintf("converting %s in the place of an identifier to %s", identifier, df.options.ParameterStyle))
+
+	case ParameterStyleNamed:
+		return "?" + name
+
+	case ParameterStyleColon:
+		return ":" + name
+	}
 
-	df.printer.PrintString("?", withIndent)
+	return identifier
 }
 
 func (df *DefaultFormatter) PrintSubSelect(ss nodes.RangeSubselect, withIndent bool) {
 	if ss.Lateral {
-		df.printer.PrintKeyword(" lateral")
+		df.printer.PrintKeyword("lateral ", withIndent)
+		withIndent = false
 	}
-	df.printer.NewLine()
-	df.printer.IncIndent()
-	df.printer.PrintString("(", true)
-	df.printer.NewLine()
+
+	df.beginGroup()
+	df.printer.PrintString("(", withIndent)
+	df.lineBreak("")
 	df.printer.IncIndent()
-	df.printNode(ss.Subquery, withIndent)
-	df.printer.NewLine()
+	df.printNode(ss.Subquery, true)
+	df.lineBreak("")
 	df.printer.DecIndent()
 	df.printer.PrintString(") ", true)
 
 	if ss.Alias != nil {
 		df.PrintAlias(*ss.Alias)
 	}
+
+	df.endGroup()
+}
+
+func (df *DefaultFormatter) PrintTypeCast(tc nodes.TypeCast, withIndent bool) {
+	// all of this garbage is required to convert an optimized 't'::bool back to a true
+	// because only crazy people prefer the later.
+	isBool := false
+	isAConst := false
+
+	switch tc.Arg.(type) {
+	case nodes.A_Const:
+		isAConst = true
+	}
+
+	if isAConst && tc.TypeName != nil && df.typeNameIs(*tc.TypeName, "boolean") {
+		isString := false
+		ac := tc.Arg.(nodes.A_Const)
+		switch ac.Val.(type) {
+		case nodes.String:
+			isString = true
+		}
+
+		if isString && ac.Val.(nodes.String).Str == "t" {
+			df.printer.PrintString("true", withIndent)
+			isBool = true
+		}
+
+		if isString && ac.Val.(nodes.String).Str == "f" {
+			df.printer.PrintString("false", withIndent)
+			isBool = true
+		}
+	}
+
+	if isBool {
+		return
+	}
+
+	if df.options.StandardCasts && tc.TypeName != nil {
+		df.printer.PrintKeyword("cast", withIndent)
+		df.printer.PrintString("(")
+		df.printNode(tc.Arg, false)
+		df.printer.PrintKeyword(" as ")
+		df.PrintTypeName(*tc.TypeName)
+		df.printer.PrintString(")")
+		return
+	}
+
+	df.printOperand(tc.Arg, precedenceTypeCast, false, tc.Location, withIndent)
+	if tc.TypeName != nil {
+		df.printer.PrintString("::")
+		df.PrintTypeName(*tc.TypeName)
+	}
+}
+
+func (df *DefaultFormatter) PrintTypeName(tn nodes.TypeName) {
+	if tn.Setof {
+		df.printer.PrintKeyword("setof ")
+	}
+
+	name, standard := df.typeName(tn)
+
+	if tn.PctType {
+		df.printer.PrintString(name)
+		df.printer.PrintKeyword("%type")
+		return
+	}
+
+	// the precision of a time zone aware type goes before the time zone
+	if base, ok := timeZoneTypes[name]; ok && standard {
+		df.printer.PrintString(base)
+		df.PrintTypeModifiers(tn.Typmods)
+		df.printer.PrintKeyword(" with time zone")
+	} else if name == "interval" && standard {
+		df.printer.PrintString(name)
+		df.PrintIntervalModifiers(tn.Typmods)
+	} else {
+		df.printer.PrintString(name)
+		df.PrintTypeModifiers(tn.Typmods)
+	}
+
+	for _, bound := range tn.ArrayBounds.Items {
+		if i, ok := bound.(nodes.Integer); ok && i.Ival >= 0 {
+			df.printer.PrintString(fmt.Sprintf("[%d]", i.Ival))
+		} else {
+			df.printer.PrintString("[]")
+		}
+	}
+}
+
+// typeName Returns the name a type is printed with and whether that is its SQL-standard spelling. Built-in types
+// are qualified with pg_catalog by the parser when they were written in their SQL-standard form, so those are
+// spelled the standard way again, other names are printed as they were written unless StandardTypeNames is set
+func (df *DefaultFormatter) typeName(tn nodes.TypeName) (string, bool) {
+	names := []string{}
+
+	for _, item := range tn.Names.Items {
+		if s, ok := item.(nodes.String); ok {
+			names = append(names, s.Str)
+		}
+	}
+
+	if len(names) == 0 {
+		return "", false
+	}
+
+	catalog := len(names) == 2 && names[0] == "pg_catalog"
+	builtIn := catalog && systemTypeNames[names[1]] && !df.writtenWithSchema(tn)
+
+	if builtIn || (df.options.StandardTypeNames && (len(names) == 1 || catalog)) {
+		name := names[len(names)-1]
+
+		if standard, ok := standardTypeNames[name]; ok {
+			return standard, true
+		}
+
+		if _, ok := timeZoneTypes[name]; ok || builtIn {
+			return name, true
+		}
+	}
+
+	for i := range names {
+		names[i] = quoteTypeName(names[i])
+	}
+
+	return strings.Join(names, "."), false
 }
 
-func (df *DefaultFormatter) PrintTypeCast(tc nodes.TypeCast, withIndent bool) {
-	// all of this garbage is required to convert an optimized 't'::bool back to a true
-	// because only crazy people prefer the later.
-	isTrue := false
-	isAConst := false
+// typeNameIs Checks whether the type is the built-in type with the given SQL-standard name
+func (df *DefaultFormatter) typeNameIs(tn nodes.TypeName, standardName string) bool {
+	name, standard := df.typeName(tn)
 
-	switch tc.Arg.(type) {
-	case nodes.A_Const:
-		isAConst = true
+	return standard && name == standardName
+}
+
+// writtenWithSchema Checks whether the source names the schema of the type, as opposed to the parser adding it
+func (df *DefaultFormatter) writtenWithSchema(tn nodes.TypeName) bool {
+	if tn.Location < 0 || tn.Location >= len(df.source) {
+		return false
 	}
 
-	if isAConst {
-		isString := false
-		ac := tc.Arg.(nodes.A_Const)
-		switch ac.Val.(type) {
-		case nodes.String:
-			isString = true
-		}
+	return strings.Contains(df.source[tn.Location:tokenEnd(df.source, tn.Location)], ".")
+}
 
-		if isString && ac.Val.(nodes.String).Str == "t" {
-			isTrue = true
+func (df *DefaultFormatter) PrintTypeModifiers(typmods nodes.List) {
+	modifiers := nodes.List{}
+
+	// modifiers the parser implies, e.g. the length of a char, are left out
+	for _, item := range typmods.Items {
+		if ac, ok := item.(nodes.A_Const); !ok || ac.Location >= 0 {
+			modifiers.Items = append(modifiers.Items, item)
 		}
 	}
 
-	if isTrue {
-		df.printer.PrintString("true", withIndent)
+	if len(modifiers.Items) > 0 {
+		df.printParenthesisedList(modifiers, false)
+	}
+}
+
+// PrintIntervalModifiers Prints the fields an interval is restricted to followed by its precision
+func (df *DefaultFormatter) PrintIntervalModifiers(typmods nodes.List) {
+	if len(typmods.Items) == 0 {
 		return
 	}
 
-	df.printNode(tc.Arg, withIndent)
-	if tc.TypeName != nil {
-		df.printer.PrintString("::")
-		df.PrintTypeName(*tc.TypeName)
+	if ac, ok := typmods.Items[0].(nodes.A_Const); ok {
+		if mask, ok := ac.Val.(nodes.Integer); ok && mask.Ival != intervalFullRange {
+			fields, ok := intervalFields[mask.Ival]
+			if !ok {
+				df.p(ac, fmt.Sprintf("Interval Fields - %d", mask.Ival))
+			}
+
+			df.printer.PrintKeyword(" " + fields)
+		}
 	}
-}
 
-func (df *DefaultFormatter) PrintTypeName(tn nodes.TypeName) {
-	if len(tn.Names.Items) > 1 {
-		df.printNode(tn.Names.Items[1], false)
+	if len(typmods.Items) > 1 {
+		df.printParenthesisedList(nodes.List{Items: typmods.Items[1:]}, false)
 	}
 }
 
 func (df *DefaultFormatter) PrintSubLink(sl nodes.SubLink, withIndent bool) {
 	switch sl.SubLinkType {
 	case nodes.ROWCOMPARE_SUBLINK,
-		nodes.MULTIEXPR_SUBLINK,
 		nodes.ARRAY_SUBLINK:
 
-		df.p(fmt.Sprintf("Unsupported sublinktype: %+v", sl.SubLinkType))
+		df.p(sl, fmt.Sprintf("Sub link type %+v", sl.SubLinkType))
 
 	case nodes.ANY_SUBLINK:
 
 		if sl.Testexpr != nil {
-			df.printNode(sl.Testexpr, withIndent)
+			df.printOperand(sl.Testexpr, expressionPrecedence(sl), false, sl.Location, withIndent)
 		}
 
 		if len(sl.OperName.Items) == 0 {
@@ -649,7 +1997,7 @@ func (df *DefaultFormatter) PrintSubLink(sl nodes.SubLink, withIndent bool) {
 	case nodes.ALL_SUBLINK:
 
 		if sl.Testexpr != nil {
-			df.printNode(sl.Testexpr, withIndent)
+			df.printOperand(sl.Testexpr, expressionPrecedence(sl), false, sl.Location, withIndent)
 		}
 
 		df.printer.PrintString(" ")
@@ -665,18 +2013,21 @@ func (df *DefaultFormatter) PrintSubLink(sl nodes.SubLink, withIndent bool) {
 
 		df.printer.PrintKeyword("exists", withIndent)
 
-	case nodes.EXPR_SUBLINK:
+	case nodes.EXPR_SUBLINK,
+		nodes.MULTIEXPR_SUBLINK:
 
-		df.printer.PrintString("", true)
+		df.printer.PrintString("", withIndent)
 	}
 
+	df.beginGroup()
 	df.printer.PrintString("(")
-	df.printer.NewLine()
+	df.lineBreak("")
 	df.printer.IncIndent()
 	df.printNode(sl.Subselect, withIndent)
-	df.printer.NewLine()
+	df.lineBreak("")
 	df.printer.DecIndent()
 	df.printer.PrintString(")", true)
+	df.endGroup()
 }
 
 func (df *DefaultFormatter) PrintRangeFunction(rf nodes.RangeFunction, withIndent bool) {
@@ -700,18 +2051,129 @@ func (df *DefaultFormatter) PrintFuncCallName(fc nodes.FuncCall, withIndent bool
 	}
 }
 
-func (df *DefaultFormatter) PrintFuncCallArgs(fc nodes.FuncCall, withIndent bool) {
-	for i, arg := range fc.Args.Items {
-		df.printNode(arg, false)
-		if i < len(fc.Args.Items)-1 {
-			df.printer.PrintString(", ")
+func (df *DefaultFormatter) PrintFuncCallArgs(fc nodes.FuncCall, expanded bool) {
+	df.printArgs(fc.Args, expanded)
+}
+
+// printArgs Prints function arguments, either on the current line or each on a line of its own
+func (df *DefaultFormatter) printArgs(args nodes.List, expanded bool) {
+	for i, arg := range args.Items {
+		if expanded && i == 0 {
+			df.lineBreak("")
+		} else if expanded {
+			df.lineBreak(" ")
+		}
+
+		df.printNode(arg, expanded)
+
+		if i < len(args.Items)-1 {
+			if expanded {
+				df.printer.PrintString(",")
+			} else {
+				df.printer.PrintString(", ")
+			}
+		}
+	}
+}
+
+// printParenthesisedArgs Prints the arguments of function like expressions, e.g. coalesce
+func (df *DefaultFormatter) printParenthesisedArgs(args nodes.List) {
+	df.printParenthesisedList(args, df.argsSpanLines(args))
+}
+
+// printParenthesisedList Prints a list in parentheses, either inline or with every item on a line of its own
+func (df *DefaultFormatter) printParenthesisedList(args nodes.List, expanded bool) {
+	df.beginGroup()
+	df.printer.PrintString("(")
+
+	if expanded {
+		df.printer.IncIndent()
+		df.printArgs(args, expanded)
+		df.printer.DecIndent()
+		df.lineBreak("")
+		df.printer.PrintString(")", true)
+	} else {
+		df.printArgs(args, expanded)
+		df.printer.PrintString(")")
+	}
+
+	df.endGroup()
+}
+
+func (df *DefaultFormatter) argsSpanLines(args nodes.List) bool {
+	// in layout mode the group around the arguments decides whether they get a line each
+	if df.layout {
+		return len(args.Items) > 0
+	}
+
+	scratch := df.scratch()
+	scratch.printArgs(args, false)
+
+	return strings.Contains(scratch.String(), "\n")
+}
+
+func (df *DefaultFormatter) PrintCoalesceExpr(ce nodes.CoalesceExpr, withIndent bool) {
+	df.printer.PrintFunction("coalesce", withIndent)
+	df.printParenthesisedArgs(ce.Args)
+}
+
+func (df *DefaultFormatter) PrintMinMaxExpr(mme nodes.MinMaxExpr, withIndent bool) {
+	if mme.Op == nodes.IS_GREATEST {
+		df.printer.PrintFunction("greatest", withIndent)
+	} else {
+		df.printer.PrintFunction("least", withIndent)
+	}
+
+	df.printParenthesisedArgs(mme.Args)
+}
+
+func (df *DefaultFormatter) PrintGroupingFunc(gf nodes.GroupingFunc, withIndent bool) {
+	df.printer.PrintFunction("grouping", withIndent)
+	df.printParenthesisedArgs(gf.Args)
+}
+
+func (df *DefaultFormatter) PrintGroupingSet(gs nodes.GroupingSet, withIndent bool) {
+	switch gs.Kind {
+	case nodes.GROUPING_SET_EMPTY:
+		df.printer.PrintString("()", withIndent)
+		return
+
+	case nodes.GROUPING_SET_SIMPLE:
+		df.printer.PrintString("", withIndent)
+		df.printArgs(gs.Content, false)
+		return
+
+	case nodes.GROUPING_SET_ROLLUP:
+		df.printer.PrintKeyword("rollup", withIndent)
+
+	case nodes.GROUPING_SET_CUBE:
+		df.printer.PrintKeyword("cube", withIndent)
+
+	case nodes.GROUPING_SET_SETS:
+		df.printer.PrintKeyword("grouping sets ", withIndent)
+
+	default:
+		df.p(gs, fmt.Sprintf("Kind %d", gs.Kind))
+	}
+
+	df.printParenthesisedList(gs.Content, df.groupingSetSpansLines(gs))
+}
+
+// groupingSetSpansLines Sets that contain column lists or other sets get a line per item, simple column lists stay inline
+func (df *DefaultFormatter) groupingSetSpansLines(gs nodes.GroupingSet) bool {
+	for _, item := range gs.Content.Items {
+		switch item.(type) {
+		case nodes.GroupingSet, nodes.RowExpr:
+			return true
 		}
 	}
+
+	return df.argsSpanLines(gs.Content)
 }
 
 func (df *DefaultFormatter) PrintFuncCallOrder(fc nodes.FuncCall, withIndent bool) {
 	if len(fc.AggOrder.Items) > 0 {
-		df.printer.PrintKeyword(" order by")
+		df.printer.PrintKeyword(" order by ")
 		for i, item := range fc.AggOrder.Items {
 			df.printNode(item, false)
 			if i < len(fc.AggOrder.Items)-1 {
@@ -723,27 +2185,34 @@ func (df *DefaultFormatter) PrintFuncCallOrder(fc nodes.FuncCall, withIndent boo
 
 func (df *DefaultFormatter) PrintFuncCallAggFilter(fc nodes.FuncCall, withIndent bool) {
 	if fc.AggFilter != nil {
-		df.printer.NewLine()
+		df.beginGroup()
+		df.lineBreak(" ")
 		df.printer.IncIndent()
 		df.printer.PrintKeyword("filter ", true)
+		df.beginGroup()
 		df.printer.PrintString("(")
-		df.printer.NewLine()
+		df.lineBreak("")
 		df.printer.IncIndent()
+		df.beginGroup()
 		df.printer.PrintKeyword("where", true)
-		df.printer.NewLine()
+		df.lineBreak(" ")
 		df.printer.IncIndent()
 		df.printNode(fc.AggFilter, true)
 		df.printer.DecIndent()
+		df.endGroup()
 		df.printer.DecIndent()
-		df.printer.NewLine()
+		df.lineBreak("")
 		df.printer.PrintString(")", true)
+		df.endGroup()
 		df.printer.DecIndent()
+		df.endGroup()
 	}
 }
 
 func (df *DefaultFormatter) PrintFuncCall(fc nodes.FuncCall, withIndent bool) {
 	df.PrintFuncCallName(fc, withIndent)
 
+	df.beginGroup()
 	df.printer.PrintString("(")
 
 	if fc.AggDistinct {
@@ -754,10 +2223,279 @@ func (df *DefaultFormatter) PrintFuncCall(fc nodes.FuncCall, withIndent bool) {
 		df.printer.PrintString("*")
 	}
 
-	df.PrintFuncCallArgs(fc, withIndent)
+	// arguments that span multiple lines, like case expressions, get a line each
+	expanded := df.argsSpanLines(fc.Args)
+
+	if expanded {
+		df.printer.IncIndent()
+	}
+
+	df.PrintFuncCallArgs(fc, expanded)
 	df.PrintFuncCallOrder(fc, withIndent)
-	df.printer.PrintString(")")
+
+	if expanded {
+		df.printer.DecIndent()
+		df.lineBreak("")
+		df.printer.PrintString(")", true)
+	} else {
+		df.printer.PrintString(")")
+	}
+
+	df.endGroup()
 	df.PrintFuncCallAggFilter(fc, withIndent)
+	df.PrintFuncCallOver(fc, withIndent)
+}
+
+func (df *DefaultFormatter) PrintFuncCallOver(fc nodes.FuncCall, withIndent bool) {
+	if fc.Over == nil {
+		return
+	}
+
+	// a reference to a named window, e.g. over w
+	if fc.Over.Name != nil {
+		df.printer.PrintKeyword(" over ")
+		df.printer.PrintString(*fc.Over.Name)
+		return
+	}
+
+	if !df.layout && df.windowSpecificationFits(*fc.Over) {
+		df.printer.PrintKeyword(" over ")
+		df.PrintWindowSpecification(*fc.Over)
+		return
+	}
+
+	df.beginGroup()
+	df.lineBreak(" ")
+	df.printer.IncIndent()
+	df.printer.PrintKeyword("over ", true)
+	df.PrintWindowSpecification(*fc.Over)
+	df.printer.DecIndent()
+	df.endGroup()
+}
+
+// PrintWindowSpecification Prints the parenthesised part of a window definition, short specifications stay on one line
+func (df *DefaultFormatter) PrintWindowSpecification(wd nodes.WindowDef) {
+	// in layout mode the group of the expanded specification decides whether it fits
+	if !df.layout && df.windowSpecificationFits(wd) {
+		df.printWindowSpecificationInline(wd)
+	} else {
+		df.printWindowSpecificationExpanded(wd)
+	}
+}
+
+func (df *DefaultFormatter) windowSpecificationFits(wd nodes.WindowDef) bool {
+	scratch := df.scratch()
+	scratch.printWindowSpecificationInline(wd)
+
+	return utf8.RuneCountInString(scratch.String()) <= maxInlineWindowWidth
+}
+
+func (df *DefaultFormatter) printWindowSpecificationInline(wd nodes.WindowDef) {
+	df.printer.PrintString("(")
+	sep := ""
+
+	if wd.Refname != nil {
+		df.printer.PrintString(*wd.Refname)
+		sep = " "
+	}
+
+	if len(wd.PartitionClause.Items) > 0 {
+		df.printer.PrintString(sep)
+		df.printer.PrintKeyword("partition by ")
+		df.printInlineList(wd.PartitionClause)
+		sep = " "
+	}
+
+	if len(wd.OrderClause.Items) > 0 {
+		df.printer.PrintString(sep)
+		df.printer.PrintKeyword("order by ")
+		df.printInlineList(wd.OrderClause)
+		sep = " "
+	}
+
+	if wd.FrameOptions&frameOptionNonDefault != 0 {
+		df.printer.PrintString(sep)
+		df.PrintWindowFrame(wd)
+	}
+
+	df.printer.PrintString(")")
+}
+
+func (df *DefaultFormatter) printWindowSpecificationExpanded(wd nodes.WindowDef) {
+	df.beginGroup()
+	df.printer.PrintString("(")
+	df.printer.IncIndent()
+	sep := ""
+
+	if wd.Refname != nil {
+		df.lineBreak(sep)
+		df.printer.PrintString(*wd.Refname, true)
+		sep = " "
+	}
+
+	if len(wd.PartitionClause.Items) > 0 {
+		df.lineBreak(sep)
+		df.beginGroup()
+		df.printer.PrintKeyword("partition by", true)
+		df.printExpandedList(wd.PartitionClause)
+		df.endGroup()
+		sep = " "
+	}
+
+	if len(wd.OrderClause.Items) > 0 {
+		df.lineBreak(sep)
+		df.beginGroup()
+		df.printer.PrintKeyword("order by", true)
+		df.printExpandedList(wd.OrderClause)
+		df.endGroup()
+		sep = " "
+	}
+
+	if wd.FrameOptions&frameOptionNonDefault != 0 {
+		df.lineBreak(sep)
+		df.printer.PrintString("", true)
+		df.PrintWindowFrame(wd)
+	}
+
+	df.printer.DecIndent()
+	df.lineBreak("")
+	df.printer.PrintString(")", true)
+	df.endGroup()
+}
+
+// PrintWindowFrame Prints the rows or range frame clause of a window definition
+func (df *DefaultFormatter) PrintWindowFrame(wd nodes.WindowDef) {
+	if wd.FrameOptions&frameOptionRows != 0 {
+		df.printer.PrintKeyword("rows ")
+	} else if wd.FrameOptions&frameOptionRange != 0 {
+		df.printer.PrintKeyword("range ")
+	}
+
+	if wd.FrameOptions&frameOptionBetween != 0 {
+		df.printer.PrintKeyword("between ")
+	}
+
+	switch {
+	case wd.FrameOptions&frameOptionStartUnboundedPreceding != 0:
+		df.printer.PrintKeyword("unbounded preceding")
+
+	case wd.FrameOptions&frameOptionStartCurrentRow != 0:
+		df.printer.PrintKeyword("current row")
+
+	case wd.FrameOptions&frameOptionStartValuePreceding != 0:
+		df.printNode(wd.StartOffset, false)
+		df.printer.PrintKeyword(" preceding")
+
+	case wd.FrameOptions&frameOptionStartValueFollowing != 0:
+		df.printNode(wd.StartOffset, false)
+		df.printer.PrintKeyword(" following")
+	}
+
+	// without between the end of the frame is implied
+	if wd.FrameOptions&frameOptionBetween == 0 {
+		return
+	}
+
+	df.printer.PrintKeyword(" and ")
+
+	switch {
+	case wd.FrameOptions&frameOptionEndUnboundedFollowing != 0:
+		df.printer.PrintKeyword("unbounded following")
+
+	case wd.FrameOptions&frameOptionEndCurrentRow != 0:
+		df.printer.PrintKeyword("current row")
+
+	case wd.FrameOptions&frameOptionEndValuePreceding != 0:
+		df.printNode(wd.EndOffset, false)
+		df.printer.PrintKeyword(" preceding")
+
+	case wd.FrameOptions&frameOptionEndValueFollowing != 0:
+		df.printNode(wd.EndOffset, false)
+		df.printer.PrintKeyword(" following")
+	}
+}
+
+// printInlineList Prints a list of nodes separated by commas on the current line
+func (df *DefaultFormatter) printInlineList(list nodes.List) {
+	for i, item := range list.Items {
+		df.printNode(item, false)
+
+		if i < len(list.Items)-1 {
+			df.printer.PrintString(", ")
+		}
+	}
+}
+
+// printExpandedList Prints an indented list of nodes with one node per line
+func (df *DefaultFormatter) printExpandedList(list nodes.List) {
+	df.printer.IncIndent()
+
+	for i, item := range list.Items {
+		df.lineBreak(" ")
+		df.printNode(item, true)
+
+		if i < len(list.Items)-1 {
+			df.printer.PrintString(",")
+		}
+	}
+
+	df.printer.DecIndent()
+}
+
+// PrintCaseExpr Prints a case expression with every when on its own line and the end lined up with the case
+func (df *DefaultFormatter) PrintCaseExpr(ce nodes.CaseExpr, withIndent bool) {
+	df.beginGroup()
+	df.printer.PrintKeyword("case", withIndent)
+
+	// simple case, i.e. case x when 1 then ...
+	if ce.Arg != nil {
+		df.printer.PrintString(" ")
+		df.printNode(ce.Arg, false)
+	}
+
+	df.printer.IncIndent()
+
+	for _, when := range ce.Args.Items {
+		df.lineBreak(" ")
+		df.printNode(when, true)
+	}
+
+	if ce.Defresult != nil {
+		df.lineBreak(" ")
+		df.printer.PrintKeyword("else", true)
+		df.printCaseResult(ce.Defresult)
+	}
+
+	df.printer.DecIndent()
+	df.lineBreak(" ")
+	df.printer.PrintKeyword("end", true)
+	df.endGroup()
+}
+
+func (df *DefaultFormatter) PrintCaseWhen(cw nodes.CaseWhen, withIndent bool) {
+	df.printer.PrintKeyword("when ", withIndent)
+
+	// conditions that span multiple lines are indented beneath the when
+	df.printer.IncIndent()
+	df.printNode(cw.Expr, false)
+	df.printer.DecIndent()
+
+	df.printer.PrintKeyword(" then")
+	df.printCaseResult(cw.Result)
+}
+
+// printCaseResult Nested case expressions start on a line of their own so that their end lines up with their case
+func (df *DefaultFormatter) printCaseResult(result nodes.Node) {
+	if _, ok := result.(nodes.CaseExpr); ok {
+		df.printer.IncIndent()
+		df.lineBreak(" ")
+		df.printNode(result, true)
+		df.printer.DecIndent()
+		return
+	}
+
+	df.printer.PrintString(" ")
+	df.printNode(result, false)
 }
 
 func (df *DefaultFormatter) PrintSortBy(sb nodes.SortBy, withIndent bool) {
@@ -770,9 +2508,72 @@ func (df *DefaultFormatter) PrintSortBy(sb nodes.SortBy, withIndent bool) {
 	}
 }
 
-// PrintNode This is the main entry point for the AST crawler
-func (df *DefaultFormatter) PrintNode(node nodes.Node) {
+// PrintNode Prints a statement, a statement that can not be printed is left out of the output and reported
+// with an UnsupportedError
+func (df *DefaultFormatter) PrintNode(node nodes.Node) (err error) {
+	state := df.state()
+
+	defer func() {
+		df.statement++
+
+		if r := recover(); r != nil {
+			ue, ok := r.(*UnsupportedError)
+			if !ok {
+				panic(r)
+			}
+
+			df.restore(state)
+			df.skipComments(df.statementEnd)
+			err = ue
+		}
+	}()
+
+	if df.options.ParameterStyle != ParameterStyleAsWritten {
+		df.numberParameters(node)
+	}
+
+	if rs, ok := node.(nodes.RawStmt); ok {
+		df.printStatement(rs)
+
+		return nil
+	}
+
 	df.printNode(node, false)
+
+	return nil
+}
+
+// printStatement Prints a statement terminated by a semicolon on the lines after the previous one, statements the
+// author kept apart with a blank line are kept apart with BlankLines blank lines
+func (df *DefaultFormatter) printStatement(rs nodes.RawStmt) {
+	// a length of 0 means the statement runs to the end of the sql, it starts right after the previous semicolon
+	df.statementStart, df.statementEnd = rs.StmtLocation, len(df.source)
+	if rs.StmtLen > 0 {
+		df.statementEnd = rs.StmtLocation + rs.StmtLen
+	}
+
+	if df.statementStart < 0 || df.statementEnd > len(df.source) {
+		df.statementStart, df.statementEnd = 0, len(df.source)
+	}
+
+	first := skipSpaceAndComments(df.source, df.statementStart)
+
+	from := df.statementStart
+	if df.commentEnd > from {
+		from = df.commentEnd
+	}
+
+	df.anchorComments(rs.Stmt)
+	df.printGap(df.printHeaderComments(from, first), first)
+	df.layout = df.options.MaxWidth > 0
+
+	df.beginGroup()
+	df.printNode(rs, false)
+	df.printer.PrintString(";")
+	df.endGroup()
+
+	df.printStatementComments()
+	df.printRemainingComments()
 }
 
 // printNode This is the main forking function that decides what to do with a node.
@@ -783,6 +2584,21 @@ func (df *DefaultFormatter) printNode(node nodes.Node, withIndent bool) {
 		return
 	}
 
+	if df.options.VerbatimFallback {
+		defer df.recoverVerbatim(node, withIndent, df.state())
+	}
+
+	if df.nextComment < len(df.comments) {
+		if location := leadingLocation(node); location >= 0 {
+			withIndent = df.printLeadingComments(location, withIndent)
+		}
+
+		// the comments after the node go at the end of the line the node starts on
+		if location := nodeLocation(node); location >= 0 {
+			df.commentsReached(location, location+1)
+		}
+	}
+
 	switch node.(type) {
 	case nodes.RawStmt:
 		df.printNode(node.(nodes.RawStmt).Stmt, withIndent)
@@ -790,6 +2606,15 @@ func (df *DefaultFormatter) printNode(node nodes.Node, withIndent bool) {
 	case nodes.SelectStmt:
 		df.PrintSelectStatement(node.(nodes.SelectStmt))
 
+	case nodes.InsertStmt:
+		df.PrintInsertStatement(node.(nodes.InsertStmt))
+
+	case nodes.UpdateStmt:
+		df.PrintUpdateStatement(node.(nodes.UpdateStmt))
+
+	case nodes.DeleteStmt:
+		df.PrintDeleteStatement(node.(nodes.DeleteStmt))
+
 	case nodes.IntoClause:
 		df.printer.PrintKeyword(" into ")
 
@@ -803,10 +2628,10 @@ func (df *DefaultFormatter) printNode(node nodes.Node, withIndent bool) {
 		df.printer.PrintString("*", withIndent)
 
 	case nodes.String:
-		df.printer.PrintString(node.(nodes.String).Str, withIndent)
+		df.printer.PrintString(df.convertIdentifierParameter(node.(nodes.String).Str), withIndent)
 
 	case nodes.JoinExpr:
-		df.PrintJoin(false, node.(nodes.JoinExpr))
+		df.PrintJoin(node.(nodes.JoinExpr))
 
 	case nodes.RangeVar:
 		df.PrintRangeVar(node.(nodes.RangeVar), withIndent)
@@ -824,12 +2649,7 @@ func (df *DefaultFormatter) printNode(node nodes.Node, withIndent bool) {
 		df.printer.PrintInt64(node.(nodes.Integer).Ival, withIndent)
 
 	case nodes.Float:
-		val, err := strconv.ParseFloat(node.(nodes.Float).Str, 64)
-		if err != nil {
-			df.p(node.(nodes.Float).Str + " failed to parse as a float")
-		}
-
-		df.printer.PrintFloat64(val, withIndent)
+		df.PrintFloat(node.(nodes.Float), withIndent)
 
 	case nodes.NullTest:
 		df.PrintNullTest(node.(nodes.NullTest), withIndent)
@@ -867,7 +2687,49 @@ func (df *DefaultFormatter) printNode(node nodes.Node, withIndent bool) {
 	case nodes.SortBy:
 		df.PrintSortBy(node.(nodes.SortBy), withIndent)
 
+	case nodes.Null:
+		df.printer.PrintKeyword("null", withIndent)
+
+	case nodes.SetToDefault:
+		df.printer.PrintKeyword("default", withIndent)
+
+	case nodes.IndexElem:
+		df.PrintIndexElem(node.(nodes.IndexElem), withIndent)
+
+	case nodes.A_Indices:
+		df.PrintAIndices(node.(nodes.A_Indices))
+
+	case nodes.A_Indirection:
+		df.PrintAIndirection(node.(nodes.A_Indirection), withIndent)
+
+	case nodes.RowExpr:
+		df.PrintRowExpr(node.(nodes.RowExpr), withIndent)
+
+	case nodes.CaseExpr:
+		df.PrintCaseExpr(node.(nodes.CaseExpr), withIndent)
+
+	case nodes.CaseWhen:
+		df.PrintCaseWhen(node.(nodes.CaseWhen), withIndent)
+
+	case nodes.CoalesceExpr:
+		df.PrintCoalesceExpr(node.(nodes.CoalesceExpr), withIndent)
+
+	case nodes.MinMaxExpr:
+		df.PrintMinMaxExpr(node.(nodes.MinMaxExpr), withIndent)
+
+	case nodes.GroupingFunc:
+		df.PrintGroupingFunc(node.(nodes.GroupingFunc), withIndent)
+
+	case nodes.GroupingSet:
+		df.PrintGroupingSet(node.(nodes.GroupingSet), withIndent)
+
+	case nodes.CurrentOfExpr:
+		df.printer.PrintKeyword("current of ", withIndent)
+		df.printer.PrintString(*node.(nodes.CurrentOfExpr).CursorName)
+
 	default:
-		df.p(fmt.Sprintf("Node: %T", node))
+		df.p(node, "Node")
 	}
+
+	df.printCommentsBeforeBracket(node)
 }
@@ -0,0 +1,77 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	nodes "github.com/pganalyze/pg_query_go/nodes"
+)
+
+// UnsupportedError Reports a node the formatter does not know how to print
+type UnsupportedError struct {
+	// NodeType is the name of the node's type, e.g. A_Expr, empty when the problem is not tied to a node
+	NodeType string
+	// Detail describes what about the node is not supported
+	Detail string
+	// Statement is the zero based index of the statement the node belongs to
+	Statement int
+	// Offset is the byte offset of the node in the sql as it was written, before helpers.ProcessNamedParameters
+	// replaced its named parameters, -1 if the parser did not record one
+	Offset int
+	// Line and Column are the one based position of Offset, 0 when Offset is unknown
+	Line   int
+	Column int
+}
+
+func (e *UnsupportedError) Error() string {
+	msg := e.reason()
+
+	if e.Line > 0 {
+		return fmt.Sprintf("statement %d, line %d, column %d: %s", e.Statement+1, e.Line, e.Column, msg)
+	}
+
+	return fmt.Sprintf("statement %d: %s", e.Statement+1, msg)
+}
+
+// reason Describes the problem without its position
+func (e *UnsupportedError) reason() string {
+	if e.NodeType != "" {
+		return e.NodeType + ": " + e.Detail + " not supported"
+	}
+
+	return e.Detail + " not supported"
+}
+
+// newUnsupportedError Creates an UnsupportedError for the node, the position is looked up in the sql it was written as
+func (df *DefaultFormatter) newUnsupportedError(node nodes.Node, detail string) *UnsupportedError {
+	err := &UnsupportedError{
+		Detail:    detail,
+		Statement: df.statement,
+		Offset:    -1,
+	}
+
+	if node == nil {
+		return err
+	}
+
+	err.NodeType = strings.TrimPrefix(fmt.Sprintf("%T", node), "pg_query.")
+	first, _ := nodeSpan(node)
+
+	err.Offset, err.Line, err.Column = df.writtenPosition(first)
+
+	return err
+}
+
+// writtenPosition Returns the byte offset and the one based line and column in the sql as it was written of an offset
+// in the source, whose named parameters were replaced, or zeros for the line and column if it is not in the source
+func (df *DefaultFormatter) writtenPosition(offset int) (int, int, int) {
+	if offset < 0 || offset > len(df.source) {
+		return offset, 0, 0
+	}
+
+	lineStart := strings.LastIndexByte(df.source[:offset], '\n') + 1
+	line := strings.Count(df.source[:offset], "\n") + 1
+
+	return len(df.writtenText(0, offset)), line, utf8.RuneCountInString(df.writtenText(lineStart, offset)) + 1
+}
@@ -0,0 +1,37 @@
+package formatters
+
+// The layout mode keeps whatever fits in MaxWidth on one line. Clauses, lists and parentheses are printed in groups,
+// the breaks between their parts are soft breaks that the printer turns into line breaks only when a group does not
+// fit, see interfaces.SqlPrinter. Without MaxWidth the groups are left out and the breaks are always line breaks
+
+// beginGroup Starts a group of parts that either all go on one line or each on a line of their own
+func (df *DefaultFormatter) beginGroup() {
+	if df.layout {
+		df.printer.BeginGroup()
+	}
+}
+
+func (df *DefaultFormatter) endGroup() {
+	if df.layout {
+		df.printer.EndGroup()
+	}
+}
+
+// lineBreak Breaks the line between two parts of a group, flat is printed instead when the group fits on one line
+func (df *DefaultFormatter) lineBreak(flat string) {
+	if df.layout {
+		df.printer.SoftBreak(flat)
+	} else {
+		df.printer.NewLine()
+	}
+}
+
+// inlineBreak Separates parts that are always kept on one line without MaxWidth, e.g. function arguments, in
+// layout mode they get a line each when they do not fit
+func (df *DefaultFormatter) inlineBreak(flat string) {
+	if df.layout {
+		df.printer.SoftBreak(flat)
+	} else {
+		df.printer.PrintString(flat)
+	}
+}
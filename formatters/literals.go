@@ -0,0 +1,88 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// quoteString Quotes a value as a standard string literal
+func quoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// quoteEscapeString Quotes a value as an escape string literal, e.g. E'line\n', with backslash escapes for the
+// characters that can not be written as they are
+func quoteEscapeString(value string) string {
+	var sb strings.Builder
+
+	sb.WriteString("E'")
+
+	for _, r := range value {
+		switch r {
+		case '\'':
+			sb.WriteString("''")
+
+		case '\\':
+			sb.WriteString(`\\`)
+
+		case '\n':
+			sb.WriteString(`\n`)
+
+		case '\r':
+			sb.WriteString(`\r`)
+
+		case '\t':
+			sb.WriteString(`\t`)
+
+		case '\b':
+			sb.WriteString(`\b`)
+
+		case '\f':
+			sb.WriteString(`\f`)
+
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\x%02x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	sb.WriteString("'")
+
+	return sb.String()
+}
+
+// quoteDollarString Quotes a value with the first dollar quote delimiter that does not occur in it
+func quoteDollarString(value string) string {
+	tag := ""
+
+	// the trailing $ stops a value that ends in part of the delimiter from closing the quote early
+	for i := 1; strings.Contains(value+"$", "$"+tag+"$"); i++ {
+		tag = fmt.Sprintf("q%d", i)
+	}
+
+	return "$" + tag + "$" + value + "$" + tag + "$"
+}
+
+// containsControlCharacters Checks for characters that a standard string literal can not show, line breaks and
+// tabs can be written as they are
+func containsControlCharacters(value string) bool {
+	for _, r := range value {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatBitString Turns the parser's b101 or x1F form of a bit string back into a literal
+func formatBitString(value string) string {
+	if len(value) == 0 {
+		return "B''"
+	}
+
+	return strings.ToUpper(value[:1]) + "'" + value[1:] + "'"
+}
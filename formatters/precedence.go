@@ -0,0 +1,337 @@
+package formatters
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dbreedt/pgPretty/helpers"
+	nodes "github.com/pganalyze/pg_query_go/nodes"
+)
+
+// Operator precedence from lowest to highest, as declared in postgres/src/backend/parser/gram.y
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceIs
+	precedenceComparison
+	precedenceIn // also between, like, ilike and similar
+	precedenceOperator
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceExponent
+	precedenceCollate
+	precedenceUnary
+	precedenceTypeCast
+	precedenceAtom
+)
+
+// nonAssociative Operators on these levels can not be chained, e.g. a = b = c is a syntax error
+func nonAssociative(precedence int) bool {
+	return precedence == precedenceIs ||
+		precedence == precedenceComparison ||
+		precedence == precedenceIn
+}
+
+// operandNeedsParentheses Checks whether the parser would bind the operand to its operator without parentheses
+func operandNeedsParentheses(operand nodes.Node, parent int, right bool) bool {
+	precedence := expressionPrecedence(operand)
+
+	if precedence < parent {
+		return true
+	}
+
+	// left associative operators only take operators of the same precedence on their left
+	return precedence == parent && (right || nonAssociative(parent))
+}
+
+func expressionPrecedence(node nodes.Node) int {
+	switch n := node.(type) {
+	case nodes.A_Expr:
+		return aExprPrecedence(n)
+
+	case nodes.BoolExpr:
+		switch n.Boolop {
+		case nodes.AND_EXPR:
+			return precedenceAnd
+
+		case nodes.OR_EXPR:
+			return precedenceOr
+		}
+
+		return precedenceNot
+
+	case nodes.NullTest:
+		return precedenceIs
+
+	case nodes.TypeCast:
+		return precedenceTypeCast
+
+	case nodes.CollateClause:
+		return precedenceCollate
+
+	case nodes.SubLink:
+		switch n.SubLinkType {
+		case nodes.ANY_SUBLINK:
+			if len(n.OperName.Items) == 0 {
+				return precedenceIn
+			}
+
+			return operatorPrecedence(operatorName(n.OperName), true)
+
+		case nodes.ALL_SUBLINK:
+			return operatorPrecedence(operatorName(n.OperName), true)
+		}
+
+	case nodes.A_Const:
+		// the parser folds a minus into a numeric constant, so it still has to be treated as an operator
+		switch v := n.Val.(type) {
+		case nodes.Integer:
+			if v.Ival < 0 {
+				return precedenceUnary
+			}
+
+		case nodes.Float:
+			if strings.HasPrefix(v.Str, "-") {
+				return precedenceUnary
+			}
+		}
+	}
+
+	return precedenceAtom
+}
+
+func aExprPrecedence(ae nodes.A_Expr) int {
+	switch ae.Kind {
+	case nodes.AEXPR_OP:
+		return operatorPrecedence(operatorName(ae.Name), ae.Lexpr != nil)
+
+	case nodes.AEXPR_OP_ANY,
+		nodes.AEXPR_OP_ALL:
+
+		return operatorPrecedence(operatorName(ae.Name), true)
+
+	case nodes.AEXPR_DISTINCT,
+		nodes.AEXPR_NOT_DISTINCT,
+		nodes.AEXPR_OF:
+
+		return precedenceIs
+
+	case nodes.AEXPR_IN,
+		nodes.AEXPR_LIKE,
+		nodes.AEXPR_ILIKE,
+		nodes.AEXPR_SIMILAR,
+		nodes.AEXPR_BETWEEN,
+		nodes.AEXPR_NOT_BETWEEN,
+		nodes.AEXPR_BETWEEN_SYM,
+		nodes.AEXPR_NOT_BETWEEN_SYM:
+
+		return precedenceIn
+	}
+
+	return precedenceAtom
+}
+
+func operatorPrecedence(op string, binary bool) int {
+	if !binary {
+		if op == "-" || op == "+" {
+			return precedenceUnary
+		}
+
+		return precedenceOperator
+	}
+
+	switch op {
+	case "<", ">", "=", "<=", ">=", "<>", "!=":
+		return precedenceComparison
+
+	case "+", "-":
+		return precedenceAdditive
+
+	case "*", "/", "%":
+		return precedenceMultiplicative
+
+	case "^":
+		return precedenceExponent
+	}
+
+	return precedenceOperator
+}
+
+// operatorName Returns the operator of a possibly schema qualified operator name
+func operatorName(name nodes.List) string {
+	if len(name.Items) == 0 {
+		return ""
+	}
+
+	if s, ok := name.Items[len(name.Items)-1].(nodes.String); ok {
+		return s.Str
+	}
+
+	return ""
+}
+
+// startsWithOperator Checks whether the printed node starts with an operator character, e.g. a negative number, which
+// would be read as part of a prefix operator that is printed right before it
+func startsWithOperator(node nodes.Node) bool {
+	switch n := node.(type) {
+	case nodes.A_Const:
+		switch v := n.Val.(type) {
+		case nodes.Integer:
+			return v.Ival < 0
+
+		case nodes.Float:
+			return strings.HasPrefix(v.Str, "-")
+		}
+
+	case nodes.A_Expr:
+		if n.Kind == nodes.AEXPR_OP && n.Lexpr == nil {
+			return true
+		}
+
+		return n.Lexpr != nil && startsWithOperator(n.Lexpr)
+
+	case nodes.TypeCast:
+		return startsWithOperator(n.Arg)
+	}
+
+	return false
+}
+
+// nodeSpan Returns the first and last token locations found in the node and all of its children
+func nodeSpan(node nodes.Node) (int, int) {
+	first, last := -1, -1
+
+	walkLocations(node, func(location int) {
+		if first < 0 || location < first {
+			first = location
+		}
+
+		if location > last {
+			last = location
+		}
+	})
+
+	return first, last
+}
+
+// walkLocations Calls visit with every known token location in the node and all of its children
+func walkLocations(node nodes.Node, visit func(location int)) {
+	walkStructs(node, func(v reflect.Value) {
+		field := v.FieldByName("Location")
+		if !field.IsValid() || field.Kind() != reflect.Int {
+			return
+		}
+
+		if location := int(field.Int()); location >= 0 {
+			visit(location)
+		}
+	})
+}
+
+// walkStructs Calls visit with the node and every one of its children
+func walkStructs(node nodes.Node, visit func(v reflect.Value)) {
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+
+		case reflect.Struct:
+			visit(v)
+
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i))
+			}
+		}
+	}
+
+	walk(reflect.ValueOf(node))
+}
+
+// paramRefs Returns the parameters in the node and all of its children in the order they were written
+func paramRefs(node nodes.Node) []nodes.ParamRef {
+	var refs []nodes.ParamRef
+
+	walkStructs(node, func(v reflect.Value) {
+		if pr, ok := v.Interface().(nodes.ParamRef); ok {
+			refs = append(refs, pr)
+		}
+	})
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Location < refs[j].Location
+	})
+
+	return refs
+}
+
+// matchingParenthesis Returns the position of the parenthesis or bracket that closes the one at open, skipping over
+// quoted text and comments, or -1 if there is none
+func matchingParenthesis(sql string, open int) int {
+	opening, closing := sql[open], closingBracket(sql[open])
+	depth := 0
+
+	for i := open; i < len(sql); i++ {
+		switch sql[i] {
+		case opening:
+			depth++
+
+		case closing:
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+
+		default:
+			if i = skipQuotedOrComment(sql, i); i < 0 {
+				return -1
+			}
+		}
+	}
+
+	return -1
+}
+
+// closingBracket Returns the bracket that closes an opening one, e.g. ) for (
+func closingBracket(c byte) byte {
+	if c == '[' {
+		return ']'
+	}
+
+	return ')'
+}
+
+// openingBracket Returns the bracket that a closing one closes, e.g. ( for )
+func openingBracket(c byte) byte {
+	if c == ']' {
+		return '['
+	}
+
+	return '('
+}
+
+// skipQuotedOrComment Returns the position of the last byte of the quoted text or comment that starts at i, i itself
+// when nothing starts there, or -1 when it is not closed
+func skipQuotedOrComment(sql string, i int) int {
+	end, closed := helpers.LiteralEnd(sql, i)
+
+	switch {
+	case !closed:
+		return -1
+
+	case end > i:
+		return end - 1
+	}
+
+	return i
+}
@@ -0,0 +1,98 @@
+package formatters
+
+import (
+	"regexp"
+	"strings"
+)
+
+// standardTypeNames The SQL-standard spelling of the built-in types the parser only knows by their internal name
+var standardTypeNames = map[string]string{
+	"bool":   "boolean",
+	"int2":   "smallint",
+	"int4":   "integer",
+	"int8":   "bigint",
+	"float4": "real",
+	"float8": "double precision",
+	"bpchar": "char",
+	"varbit": "bit varying",
+}
+
+// systemTypeNames The built-in types the parser qualifies with pg_catalog when they are written in their SQL-standard
+// form, only these are printed without the schema
+var systemTypeNames = map[string]bool{
+	"bool": true, "int2": true, "int4": true, "int8": true, "float4": true, "float8": true, "numeric": true,
+	"bit": true, "varbit": true, "bpchar": true, "varchar": true, "timestamp": true, "timestamptz": true,
+	"time": true, "timetz": true, "interval": true,
+}
+
+// typeNameKeywords The reserved and column name keywords, see postgres/src/include/parser/kwlist.h, a type name that
+// is one of them has to be quoted
+var typeNameKeywords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+	"asc": true, "asymmetric": true, "between": true, "bigint": true, "bit": true, "boolean": true,
+	"both": true, "case": true, "cast": true, "char": true, "character": true, "check": true, "coalesce": true,
+	"collate": true, "column": true, "constraint": true, "create": true, "current_catalog": true,
+	"current_date": true, "current_role": true, "current_time": true, "current_timestamp": true,
+	"current_user": true, "dec": true, "decimal": true, "default": true, "deferrable": true, "desc": true,
+	"distinct": true, "do": true, "else": true, "end": true, "except": true, "exists": true, "extract": true,
+	"false": true, "fetch": true, "float": true, "for": true, "foreign": true, "from": true, "grant": true,
+	"greatest": true, "group": true, "grouping": true, "having": true, "in": true, "initially": true,
+	"inout": true, "int": true, "integer": true, "intersect": true, "interval": true, "into": true,
+	"lateral": true, "leading": true, "least": true, "limit": true, "localtime": true, "localtimestamp": true,
+	"national": true, "nchar": true, "none": true, "not": true, "null": true, "nullif": true, "numeric": true,
+	"offset": true, "on": true, "only": true, "or": true, "order": true, "out": true, "overlay": true,
+	"placing": true, "position": true, "precision": true, "primary": true, "real": true, "references": true,
+	"returning": true, "row": true, "select": true, "session_user": true, "setof": true, "smallint": true,
+	"some": true, "substring": true, "symmetric": true, "table": true, "then": true, "time": true,
+	"timestamp": true, "to": true, "trailing": true, "treat": true, "trim": true, "true": true, "union": true,
+	"unique": true, "user": true, "using": true, "values": true, "varchar": true, "variadic": true,
+	"when": true, "where": true, "window": true, "with": true, "xmlattributes": true, "xmlconcat": true,
+	"xmlelement": true, "xmlexists": true, "xmlforest": true, "xmlnamespaces": true, "xmlparse": true,
+	"xmlpi": true, "xmlroot": true, "xmlserialize": true, "xmltable": true,
+}
+
+// plainTypeName A name part that can be written without quotes, the parser folds anything else to lower case
+var plainTypeName = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+
+// quoteTypeName Quotes a part of a type name when it would not be read back as the same name without quotes
+func quoteTypeName(name string) string {
+	if plainTypeName.MatchString(name) && !typeNameKeywords[name] {
+		return name
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// timeZoneTypes The time types that carry a time zone, their precision goes between the name and the time zone
+var timeZoneTypes = map[string]string{
+	"timestamptz": "timestamp",
+	"timetz":      "time",
+}
+
+// Interval field masks, see INTERVAL_MASK in postgres/src/include/utils/datetime.h
+const (
+	intervalMonth     = 1 << 1
+	intervalYear      = 1 << 2
+	intervalDay       = 1 << 3
+	intervalHour      = 1 << 10
+	intervalMinute    = 1 << 11
+	intervalSecond    = 1 << 12
+	intervalFullRange = 0x7FFF
+)
+
+// intervalFields The field qualifiers an interval can be restricted to
+var intervalFields = map[int64]string{
+	intervalYear:                 "year",
+	intervalMonth:                "month",
+	intervalDay:                  "day",
+	intervalHour:                 "hour",
+	intervalMinute:               "minute",
+	intervalSecond:               "second",
+	intervalYear | intervalMonth: "year to month",
+	intervalDay | intervalHour:   "day to hour",
+	intervalDay | intervalHour | intervalMinute:                  "day to minute",
+	intervalDay | intervalHour | intervalMinute | intervalSecond: "day to second",
+	intervalHour | intervalMinute:                                "hour to minute",
+	intervalHour | intervalMinute | intervalSecond:               "hour to second",
+	intervalMinute | intervalSecond:                              "minute to second",
+}
@@ -0,0 +1,310 @@
+package formatters
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dbreedt/pgPretty/helpers"
+	nodes "github.com/pganalyze/pg_query_go/nodes"
+)
+
+// VerbatimRegion A part of the sql that could not be formatted and was copied to the output as it was written
+type VerbatimRegion struct {
+	// Offset and Length are the bytes of the sql as it was written that were copied
+	Offset int
+	Length int
+	// Line and Column are the one based position of Offset
+	Line   int
+	Column int
+	// Cause is the reason the region could not be formatted
+	Cause *UnsupportedError
+}
+
+func (r VerbatimRegion) String() string {
+	return fmt.Sprintf("statement %d, line %d, column %d: %d bytes copied as written, %s",
+		r.Cause.Statement+1, r.Line, r.Column, r.Length, r.Cause.reason())
+}
+
+// VerbatimRegions Returns the parts of the sql that were copied as written because they could not be formatted
+func (df *DefaultFormatter) VerbatimRegions() []VerbatimRegion {
+	return df.verbatim
+}
+
+// recoverVerbatim Is deferred by printNode in fallback mode, it replaces whatever was printed for node with the
+// source text of node when printing it ran into something unsupported
+func (df *DefaultFormatter) recoverVerbatim(node nodes.Node, withIndent bool, state formatterState) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	ue, ok := r.(*UnsupportedError)
+	if !ok {
+		panic(r)
+	}
+
+	start, end, ok := df.verbatimSpan(node)
+	if !ok {
+		// leave it to a node further up that knows where it starts and ends
+		panic(r)
+	}
+
+	df.restore(state)
+
+	// the comments in the region are part of its text
+	withIndent = df.printLeadingComments(start, withIndent)
+	text := df.writtenText(start, end)
+	df.printLines(text, start, withIndent)
+	df.skipComments(end)
+	df.commentsReached(start, end)
+
+	offset, line, column := df.writtenPosition(start)
+	df.verbatim = append(df.verbatim, VerbatimRegion{
+		Offset: offset,
+		Length: len(text),
+		Line:   line,
+		Column: column,
+		Cause:  ue,
+	})
+}
+
+// verbatimSpan Finds the source text of a node. The parser only records where tokens start, so the end is
+// the end of the last token plus the parentheses and brackets still open at that point
+func (df *DefaultFormatter) verbatimSpan(node nodes.Node) (int, int, bool) {
+	if rs, ok := node.(nodes.RawStmt); ok {
+		start, end := skipSpaceAndComments(df.source, df.statementStart), df.statementEnd
+
+		for end > start && isSpace(df.source[end-1]) {
+			end--
+		}
+
+		return start, end, rs.StmtLocation >= 0 && start < end
+	}
+
+	// nodes without a location of their own, e.g. statements, do not start at their first child
+	location := reflect.ValueOf(node).FieldByName("Location")
+	if !location.IsValid() || location.Int() < 0 {
+		return 0, 0, false
+	}
+
+	first, last := nodeSpan(node)
+	if first < df.statementStart || last >= df.statementEnd {
+		return 0, 0, false
+	}
+
+	start, end := first, tokenEnd(df.source, last)
+	opened, unopened := []int{}, []byte{}
+
+	for i := start; i < end && i >= 0; i++ {
+		switch c := df.source[i]; c {
+		case '(', '[':
+			opened = append(opened, i)
+
+		case ')', ']':
+			if len(opened) == 0 {
+				unopened = append(unopened, openingBracket(c))
+			} else {
+				opened = opened[:len(opened)-1]
+			}
+
+		default:
+			i = skipQuotedOrComment(df.source, i)
+		}
+	}
+
+	// the first token can be inside parentheses that belong to the node, e.g. (a + b) is distinct from c
+	for _, bracket := range unopened {
+		start--
+
+		for start > df.statementStart && isSpace(df.source[start]) {
+			start--
+		}
+
+		if df.source[start] != bracket {
+			return 0, 0, false
+		}
+	}
+
+	if len(opened) > 0 {
+		if end = matchingParenthesis(df.source, opened[0]) + 1; end == 0 {
+			return 0, 0, false
+		}
+	}
+
+	if end > df.statementEnd {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// printLines Prints text that was copied from the sql at start at the current indent, the lines after the first
+// keep their indentation relative to the column the text started in. Lines inside a string constant, quoted
+// identifier or dollar quoted body are part of its value, so they are printed byte for byte
+func (df *DefaultFormatter) printLines(text string, start int, withIndent bool) {
+	lines := strings.Split(text, "\n")
+	inLiteral := literalLines(text)
+	_, _, column := df.writtenPosition(start)
+	column--
+
+	for i, line := range lines {
+		if i > 0 {
+			df.printer.NewLine()
+		}
+
+		if !inLiteral[i+1] {
+			line = strings.TrimRight(line, " \t\r")
+		}
+
+		switch {
+		case i == 0:
+			df.printer.PrintString(line, withIndent)
+
+		case inLiteral[i]:
+			df.printer.PrintString(line)
+
+		case line != "":
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			if indent > column {
+				indent = column
+			}
+
+			df.printer.PrintString(line[indent:], true)
+		}
+	}
+}
+
+// literalLines Returns the lines of the text that start inside a string constant, quoted identifier or dollar quoted
+// body, numbered from 0
+func literalLines(text string) map[int]bool {
+	lines := map[int]bool{}
+	line := 0
+
+	for i := 0; i < len(text); {
+		end, _ := helpers.LiteralEnd(text, i)
+		if end == i {
+			end++
+		}
+
+		literal := end > i+1 && !isComment(text, i)
+
+		for _, c := range []byte(text[i:end]) {
+			if c == '\n' {
+				line++
+				lines[line] = literal
+			}
+		}
+
+		i = end
+	}
+
+	return lines
+}
+
+// writtenText Returns the source text between start and end with the named parameters put back
+func (df *DefaultFormatter) writtenText(start, end int) string {
+	sb := strings.Builder{}
+
+	for i := start; i < end; i++ {
+		name, ok := df.detectedParameters[i]
+
+		switch {
+		case ok && df.source[i] == '?':
+			sb.WriteString(name)
+
+		// a parameter in the place of an identifier is quoted, see helpers.ProcessNamedParameters
+		case ok && df.source[i] == '"':
+			sb.WriteString(name)
+			i += len(name) + 1
+
+		default:
+			sb.WriteByte(df.source[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// tokenEnd Returns the position just past the token that starts at i
+func tokenEnd(sql string, i int) int {
+	if i >= len(sql) {
+		return len(sql)
+	}
+
+	switch c := sql[i]; {
+	case c == '(' || c == '[':
+		if close := matchingParenthesis(sql, i); close >= 0 {
+			return close + 1
+		}
+
+		return len(sql)
+
+	case c == '\'' || c == '"':
+		return quotedTokenEnd(sql, i)
+
+	case c == '$':
+		if end, _ := helpers.LiteralEnd(sql, i); end > i {
+			return end
+		}
+
+		// a positional parameter, e.g. $1
+		fallthrough
+
+	case isWordChar(c):
+		for i < len(sql) && isWordChar(sql[i]) {
+			i++
+		}
+
+		// string constants with a prefix, e.g. E'\n' or B'101'
+		if i < len(sql) && sql[i] == '\'' {
+			return quotedTokenEnd(sql, i)
+		}
+
+		// the rest of a qualified name
+		if i+1 < len(sql) && sql[i] == '.' && (sql[i+1] == '"' || sql[i+1] == '*' || isWordChar(sql[i+1])) {
+			return tokenEnd(sql, i+1)
+		}
+
+		return i
+
+	case strings.IndexByte(operatorChars, c) >= 0:
+		for i < len(sql) && strings.IndexByte(operatorChars, sql[i]) >= 0 {
+			i++
+		}
+
+		return i
+	}
+
+	return i + 1
+}
+
+const operatorChars = "+-*/<>=~!@#%^&|`?"
+
+// quotedTokenEnd Returns the position just past the quoted text that starts at i, including the rest of a
+// qualified name that follows it
+func quotedTokenEnd(sql string, i int) int {
+	end, _ := helpers.LiteralEnd(sql, i)
+
+	if sql[i] == '"' && end+1 < len(sql) && sql[end] == '.' {
+		return tokenEnd(sql, end+1)
+	}
+
+	return end
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isComment(sql string, i int) bool {
+	return strings.HasPrefix(sql[i:], "--") || strings.HasPrefix(sql[i:], "/*")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
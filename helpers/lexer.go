@@ -0,0 +1,120 @@
+package helpers
+
+import "strings"
+
+// LiteralEnd Returns the offset just after the literal that starts at start, or start itself when none starts
+// there. A literal is text the formatter copies as written: a string constant, including E'\n' ones where a backslash
+// escapes the next character, a quoted identifier, a dollar quoted body, a line comment up to the end of its line or
+// a block comment, which nests. closed is false when the literal runs to the end of the sql without being closed
+func LiteralEnd(sql string, start int) (end int, closed bool) {
+	if start >= len(sql) {
+		return start, true
+	}
+
+	afterIdentifier := start > 0 && isIdentifierChar(sql[start-1])
+
+	switch c := sql[start]; {
+	case c == '\'':
+		// the prefix of an escape string constant is only one when it does not end an identifier
+		escapes := start > 0 && (sql[start-1] == 'e' || sql[start-1] == 'E') &&
+			(start == 1 || !isIdentifierChar(sql[start-2]))
+
+		return quotedEnd(sql, start, escapes)
+
+	case (c == 'e' || c == 'E') && !afterIdentifier && start+1 < len(sql) && sql[start+1] == '\'':
+		return quotedEnd(sql, start+1, true)
+
+	case c == '"':
+		return quotedEnd(sql, start, false)
+
+	case c == '$' && !afterIdentifier:
+		return dollarQuotedEnd(sql, start)
+
+	case strings.HasPrefix(sql[start:], "--"):
+		if end = strings.IndexByte(sql[start:], '\n'); end < 0 {
+			return len(sql), true
+		}
+
+		return start + end, true
+
+	case strings.HasPrefix(sql[start:], "/*"):
+		return blockCommentEnd(sql, start)
+	}
+
+	return start, true
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9') || c == '$'
+}
+
+// quotedEnd Returns the offset just after the quoted text that starts at start, a doubled quote does not end it
+func quotedEnd(sql string, start int, backslashEscapes bool) (int, bool) {
+	quote := sql[start]
+
+	for i := start + 1; i < len(sql); i++ {
+		switch {
+		case backslashEscapes && sql[i] == '\\':
+			i++
+
+		case sql[i] == quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+
+			return i + 1, true
+		}
+	}
+
+	return len(sql), false
+}
+
+// dollarQuotedEnd Returns the offset just after the dollar quoted body that starts at start, or start when it does
+// not start one, e.g. $1
+func dollarQuotedEnd(sql string, start int) (int, bool) {
+	i := start + 1
+	for i < len(sql) && sql[i] != '$' && isIdentifierChar(sql[i]) {
+		i++
+	}
+
+	if i >= len(sql) || sql[i] != '$' || (i > start+1 && !isIdentifierStart(sql[start+1])) {
+		return start, true
+	}
+
+	delimiter := sql[start : i+1]
+
+	end := strings.Index(sql[i+1:], delimiter)
+	if end < 0 {
+		return len(sql), false
+	}
+
+	return i + 1 + end + len(delimiter), true
+}
+
+// blockCommentEnd Returns the offset just after the comment that starts at start, block comments nest
+func blockCommentEnd(sql string, start int) (int, bool) {
+	depth := 0
+
+	for i := start; i+1 < len(sql); i++ {
+		switch {
+		case sql[i] == '/' && sql[i+1] == '*':
+			depth++
+			i++
+
+		case sql[i] == '*' && sql[i+1] == '/':
+			depth--
+			i++
+
+			if depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+
+	return len(sql), false
+}
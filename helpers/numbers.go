@@ -0,0 +1,60 @@
+package helpers
+
+import "strings"
+
+// NormaliseNumber Rewrites the text of a numeric constant without changing its value or type: a lower case e,
+// no leading zeros, no trailing zeros in the fraction and no sign or leading zeros in a positive exponent
+func NormaliseNumber(text string) string {
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign = "-"
+		text = text[1:]
+	}
+
+	mantissa, exponent := text, ""
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		mantissa, exponent = text[:i], text[i+1:]
+	}
+
+	whole, fraction, hasPoint := mantissa, "", false
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		whole, fraction, hasPoint = mantissa[:i], mantissa[i+1:], true
+	}
+
+	whole = strings.TrimLeft(whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+
+	mantissa = whole
+
+	// the decimal point is what makes the constant a numeric instead of an integer, so it has to stay
+	if hasPoint {
+		fraction = strings.TrimRight(fraction, "0")
+		if fraction == "" {
+			fraction = "0"
+		}
+
+		mantissa += "." + fraction
+	}
+
+	if len(exponent) == 0 {
+		return sign + mantissa
+	}
+
+	exponentSign := ""
+	if exponent[0] == '+' || exponent[0] == '-' {
+		if exponent[0] == '-' {
+			exponentSign = "-"
+		}
+
+		exponent = exponent[1:]
+	}
+
+	exponent = strings.TrimLeft(exponent, "0")
+	if exponent == "" {
+		exponent = "0"
+	}
+
+	return sign + mantissa + "e" + exponentSign + exponent
+}
@@ -2,27 +2,205 @@ package helpers
 
 import (
 	"reflect"
-	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go"
 )
 
 /*
 ProcessNamedParameters Scans the given sql and extracts any named parameters and replaces them with
 	bare parameters.
-	ORMs like go-pg/pg have support for named sql parameter placeholders like `?ClientID`, where
-	Postgres sql syntax only supports bare `?` parameter place holders
+	ORMs like go-pg/pg have support for named sql parameter placeholders like `?ClientID` or indexed ones
+	like `?0` and sqlx uses `:client_id`, where Postgres sql syntax only supports bare `?` parameter place
+	holders.
+
+	Postgres does not accept a parameter where it expects an identifier, e.g. `from ?TableName`, so when
+	the sql does not parse those placeholders are replaced with a quoted identifier of themselves instead,
+	e.g. `from "?TableName"`, see IsPlaceholderIdentifier.
 
-	Note: This approach is very naive and does not support sql with a mix of named and bare parameters
+	The returned map is keyed by the byte offset of each replaced parameter in the returned sql, which is
+	the location the parser reports for it, or the offset of the opening quote of the identifier it was
+	replaced with. Quoted text, quoted identifiers, dollar quoted bodies and comments are left alone.
 */
 func ProcessNamedParameters(sql string) (string, map[int]string) {
-	compRegEx := regexp.MustCompile(`(\?\w+)`)
-
 	retVal := make(map[int]string)
+	sb := strings.Builder{}
+	brackets := 0
+
+	for i := 0; i < len(sql); {
+		if literal, _ := LiteralEnd(sql, i); literal > i {
+			sb.WriteString(sql[i:literal])
+			i = literal
+
+			continue
+		}
+
+		c := sql[i]
+		end := i + 1
+
+		switch {
+		case c == '[':
+			brackets++
+
+		case c == ']':
+			brackets--
+
+		case c == ':' && strings.HasPrefix(sql[i:], "::"):
+			// a type cast
+			end = i + 2
+
+		case c == '?' && i+1 < len(sql) && isIdentifierChar(sql[i+1]),
+			c == ':' && brackets == 0 && i+1 < len(sql) && isIdentifierStart(sql[i+1]):
+
+			// a colon inside brackets separates the bounds of an array slice
+			end = i + 1
+			for end < len(sql) && isIdentifierChar(sql[end]) {
+				end++
+			}
+
+			retVal[sb.Len()] = sql[i:end]
+			sb.WriteString("?")
+			i = end
+
+			continue
+		}
+
+		sb.WriteString(sql[i:end])
+		i = end
+	}
 
-	for i, match := range compRegEx.FindAllString(sql, -1) {
-		retVal[i] = match
+	return substituteIdentifierParameters(sb.String(), retVal)
+}
+
+// IsPlaceholderIdentifier Checks whether an identifier stands in for a placeholder in an identifier position
+func IsPlaceholderIdentifier(identifier string) bool {
+	return strings.HasPrefix(identifier, "?") || strings.HasPrefix(identifier, ":")
+}
+
+// PlaceholderName Returns the name of a placeholder without its prefix, an indexed one, e.g. ?0, is named after its
+// one based position, e.g. p1
+func PlaceholderName(placeholder string) string {
+	name := placeholder[1:]
+
+	if index, err := strconv.Atoi(name); err == nil && strings.HasPrefix(placeholder, "?") {
+		return "p" + strconv.Itoa(index+1)
 	}
 
-	return compRegEx.ReplaceAllString(sql, "?"), retVal
+	return name
+}
+
+// ParameterNumber Returns the position a placeholder was written with, e.g. 2 for $2, or 0 if it has none
+func ParameterNumber(placeholder string) int {
+	if strings.HasPrefix(placeholder, "$") {
+		number, _ := strconv.Atoi(placeholder[1:])
+		return number
+	}
+
+	// go-pg counts its indexed parameters, e.g. ?0, from zero
+	if index, err := strconv.Atoi(placeholder[1:]); err == nil && strings.HasPrefix(placeholder, "?") {
+		return index + 1
+	}
+
+	return 0
+}
+
+// NumberParameters Returns the position of each of the placeholders of a statement, given in the order they were
+// written. Numbered ones keep theirs, every use of a name shares the position it was first given and the others
+// follow the highest numbered one
+func NumberParameters(placeholders []string) []int {
+	numbers := make([]int, len(placeholders))
+	highest := 0
+
+	for _, placeholder := range placeholders {
+		if number := ParameterNumber(placeholder); number > highest {
+			highest = number
+		}
+	}
+
+	named := make(map[string]int)
+
+	for i, placeholder := range placeholders {
+		if numbers[i] = ParameterNumber(placeholder); numbers[i] > 0 {
+			continue
+		}
+
+		name := placeholder[1:]
+		if number, ok := named[name]; ok && len(name) > 0 {
+			numbers[i] = number
+			continue
+		}
+
+		highest++
+		named[name] = highest
+		numbers[i] = highest
+	}
+
+	return numbers
+}
+
+// substituteIdentifierParameters Replaces the parameters the parser rejects with quoted identifiers. All of them
+// are replaced first, then each one is turned back into a parameter as long as the sql still parses
+func substituteIdentifierParameters(sql string, parameters map[int]string) (string, map[int]string) {
+	if len(parameters) == 0 || parses(sql) {
+		return sql, parameters
+	}
+
+	offsets := make([]int, 0, len(parameters))
+	identifiers := make(map[int]bool)
+
+	for offset := range parameters {
+		offsets = append(offsets, offset)
+		identifiers[offset] = true
+	}
+
+	sort.Ints(offsets)
+
+	// the parameters are replaced by something longer, so everything after them moves
+	substitute := func() (string, map[int]string) {
+		sb := strings.Builder{}
+		substituted := make(map[int]string)
+		last := 0
+
+		for _, offset := range offsets {
+			sb.WriteString(sql[last:offset])
+
+			substituted[sb.Len()] = parameters[offset]
+
+			if identifiers[offset] {
+				sb.WriteString(`"` + parameters[offset] + `"`)
+			} else {
+				sb.WriteString("?")
+			}
+
+			last = offset + 1
+		}
+
+		sb.WriteString(sql[last:])
+
+		return sb.String(), substituted
+	}
+
+	// let the parser report the original error if even this does not help
+	if s, _ := substitute(); !parses(s) {
+		return sql, parameters
+	}
+
+	for _, offset := range offsets {
+		identifiers[offset] = false
+
+		if s, _ := substitute(); !parses(s) {
+			identifiers[offset] = true
+		}
+	}
+
+	return substitute()
+}
+
+func parses(sql string) bool {
+	_, err := pg_query.Parse(sql)
+	return err == nil
 }
 
 // NilCheck Generic nil check
@@ -6,6 +6,7 @@ import nodes "github.com/pganalyze/pg_query_go/nodes"
 PgSqlFormatter Basic interface for a postgres AST formatter
 */
 type PgSqlFormatter interface {
-	PrintNode(node nodes.Node)
+	SetSource(sql string)
+	PrintNode(node nodes.Node) error
 	String() string
 }
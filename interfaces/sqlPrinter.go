@@ -1,5 +1,13 @@
 package interfaces
 
+// PrinterState A snapshot of a printer's output and indentation that the printer can be restored to
+type PrinterState struct {
+	Length int
+	Indent int
+	// Buffered is what was printed into groups that are still open, see SqlPrinter.BeginGroup
+	Buffered int
+}
+
 type SqlPrinter interface {
 	PrintString(val string, withIndent ...bool)
 	PrintInt(val int, withIndent ...bool)
@@ -10,5 +18,15 @@ type SqlPrinter interface {
 	IncIndent()
 	DecIndent()
 	NewLine()
+	// SetMaxWidth Sets the width groups are laid out to fit in, 0 turns groups off and makes every soft break a line break
+	SetMaxWidth(width int)
+	// BeginGroup Starts a group, the soft breaks of a group either all break or, when the group fits on the rest of
+	// the line, all print their flat text
+	BeginGroup()
+	EndGroup()
+	// SoftBreak Prints a line break, or flat when its group fits on one line
+	SoftBreak(flat string)
+	State() PrinterState
+	Restore(state PrinterState)
 	String() string
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/dbreedt/pgPretty/formatters"
 	helpers "github.com/dbreedt/pgPretty/helpers"
+	"github.com/dbreedt/pgPretty/interfaces"
 	printers "github.com/dbreedt/pgPretty/printers"
 	"github.com/dbreedt/pgPretty/processors"
 )
@@ -19,13 +20,37 @@ func main() {
 		capsKeywords    bool
 		capsFunctions   bool
 		numIndentations int
+		alignValues     bool
+		keepParentheses bool
+		standardTypes   bool
+		standardCasts   bool
+		dollarQuote     int
+		normaliseNums   bool
+		parameterStyle  string
+		verbatim        bool
+		blankLines      int
+		verify          bool
+		idempotency     bool
+		maxWidth        int
 	)
 	flag.StringVar(&fileName, "f", "", "name of the sql file you want formatted")
 	flag.BoolVar(&useTabs, "t", false, "use tabs instead of spaces (default is spaces)")
 	flag.BoolVar(&capsKeywords, "u", false, "use upper case keywords (default is lower case)")
 	flag.BoolVar(&capsFunctions, "uf", false, "use upper case function names (default is lower case)")
 	flag.IntVar(&numIndentations, "i", 2, "how many tabs/spaces to use for a single indent (default 2)")
+	flag.BoolVar(&alignValues, "av", false, "align the columns of multi-row values lists")
+	flag.BoolVar(&keepParentheses, "kp", false, "keep redundant parentheses around expressions")
+	flag.BoolVar(&standardTypes, "st", false, "print built-in types with their SQL-standard names, e.g. integer instead of int4")
+	flag.BoolVar(&standardCasts, "sc", false, "print casts as cast(x as t) instead of x::t")
+	flag.IntVar(&dollarQuote, "dq", 0, "dollar quote string literals longer than this or spanning lines (default 0, never)")
+	flag.BoolVar(&normaliseNums, "nn", false, "normalise numeric constants, e.g. 1.50E+3 as 1.5e3")
+	flag.StringVar(&parameterStyle, "ps", "", "convert parameters to one placeholder style: $n, ?, ?name or :name (default as written)")
 
+	flag.BoolVar(&verbatim, "vf", false, "copy the parts that can not be formatted as they were written instead of failing")
+	flag.IntVar(&blankLines, "bl", formatters.DefaultBlankLines, "blank lines between statements that were written with blank lines between them")
+	flag.BoolVar(&verify, "vr", false, "verify that the formatted sql parses into the same tree and print nothing if it does not")
+	flag.BoolVar(&idempotency, "ic", false, "check that formatting the output again changes nothing and print nothing if it does")
+	flag.IntVar(&maxWidth, "w", 0, "keep what fits in this many columns on one line (default 0, a line per clause and item)")
 	flag.Parse()
 
 	sql := `
@@ -82,23 +107,65 @@ func main() {
 	if fileName != "" {
 		data, err := ioutil.ReadFile(fileName)
 		if err != nil {
-			fmt.Println("Failed to open file", fileName, err)
+			fmt.Fprintln(os.Stderr, "Failed to open file", fileName, err)
 			os.Exit(1)
 		}
 
 		sql = string(data)
 	}
 
+	switch formatters.ParameterStyle(parameterStyle) {
+	case formatters.ParameterStyleAsWritten,
+		formatters.ParameterStylePositional,
+		formatters.ParameterStyleBare,
+		formatters.ParameterStyleNamed,
+		formatters.ParameterStyleColon:
+
+	default:
+		fmt.Fprintln(os.Stderr, "Unknown parameter style", parameterStyle)
+		os.Exit(1)
+	}
+
 	// remove any illegal named parameters and store them for later processing
 	workingSQL, detectedParameters := helpers.ProcessNamedParameters(sql)
 	printer := printers.NewBasePrinter(useTabs, capsKeywords, capsFunctions, numIndentations)
-	formatter := formatters.NewDefaultFormatterWithParameters(printer, detectedParameters)
+	options := formatters.FormatterOptions{
+		AlignValues:       alignValues,
+		KeepParentheses:   keepParentheses,
+		StandardTypeNames: standardTypes,
+		StandardCasts:     standardCasts,
+		DollarQuoteLength: dollarQuote,
+		NormaliseNumbers:  normaliseNums,
+		ParameterStyle:    formatters.ParameterStyle(parameterStyle),
+		VerbatimFallback:  verbatim,
+		BlankLines:        blankLines,
+		MaxWidth:          maxWidth,
+	}
+	formatter := formatters.NewDefaultFormatterWithOptions(printer, detectedParameters, options)
+	processOptions := processors.Options{Verify: verify, Parameters: detectedParameters}
 
-	prettySql, err := processors.ProcessSQL(workingSQL, formatter)
+	prettySql, err := processors.ProcessSQLWithOptions(workingSQL, formatter, processOptions)
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
+	if idempotency {
+		newFormatter := func(parameters map[int]string) interfaces.PgSqlFormatter {
+			printer := printers.NewBasePrinter(useTabs, capsKeywords, capsFunctions, numIndentations)
+
+			return formatters.NewDefaultFormatterWithOptions(printer, parameters, options)
+		}
+
+		if err := processors.CheckIdempotency(prettySql, newFormatter, processOptions); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	for _, region := range formatter.VerbatimRegions() {
+		fmt.Fprintln(os.Stderr, "warning:", region)
+	}
+
 	fmt.Println(prettySql)
 }
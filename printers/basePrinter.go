@@ -2,7 +2,10 @@ package printers
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/dbreedt/pgPretty/interfaces"
 )
 
 type caseFormatter func(s string) string
@@ -14,6 +17,11 @@ type BasePrinter struct {
 	functionFormatter caseFormatter
 	sb                strings.Builder
 	indentCache       map[int]string
+	// maxWidth is the width groups are laid out in, 0 when they are not
+	maxWidth int
+	// buffer holds what is printed while a group is open, it is laid out when the outermost group ends
+	buffer []token
+	depth  int
 }
 
 // NewBasePrinter Creates a custom BasePrinter
@@ -73,10 +81,7 @@ func (bp *BasePrinter) makeIndent() string {
 }
 
 func (bp *BasePrinter) PrintString(val string, withIndent ...bool) {
-	if len(withIndent) > 0 && withIndent[0] {
-		bp.sb.WriteString(bp.makeIndent())
-	}
-	bp.sb.WriteString(val)
+	bp.write(val, withIndent)
 }
 
 func (bp *BasePrinter) PrintInt(val int, withIndent ...bool) {
@@ -84,17 +89,54 @@ func (bp *BasePrinter) PrintInt(val int, withIndent ...bool) {
 }
 
 func (bp *BasePrinter) PrintInt64(val int64, withIndent ...bool) {
-	if len(withIndent) > 0 && withIndent[0] {
-		bp.sb.WriteString(bp.makeIndent())
-	}
-	bp.sb.WriteString(fmt.Sprintf("%d", val))
+	bp.write(fmt.Sprintf("%d", val), withIndent)
 }
 
 func (bp *BasePrinter) PrintFloat64(val float64, withIndent ...bool) {
+	bp.write(strconv.FormatFloat(val, 'g', -1, 64), withIndent)
+}
+
+func (bp *BasePrinter) write(val string, withIndent []bool) {
+	indent := ""
 	if len(withIndent) > 0 && withIndent[0] {
-		bp.sb.WriteString(bp.makeIndent())
+		indent = bp.makeIndent()
+	}
+
+	if bp.depth > 0 {
+		bp.buffer = append(bp.buffer, token{kind: textToken, text: val, indent: indent})
+		return
+	}
+
+	bp.sb.WriteString(indent)
+	bp.sb.WriteString(val)
+}
+
+func (bp *BasePrinter) State() interfaces.PrinterState {
+	return interfaces.PrinterState{
+		Length:   bp.sb.Len(),
+		Indent:   bp.currentIndent,
+		Buffered: len(bp.buffer),
+	}
+}
+
+// Restore Drops everything printed since the state was taken
+func (bp *BasePrinter) Restore(state interfaces.PrinterState) {
+	if state.Length < bp.sb.Len() {
+		printed := bp.sb.String()
+
+		bp.sb.Reset()
+		bp.sb.WriteString(printed[:state.Length])
+
+		// the groups open at the time have been laid out since
+		bp.buffer = nil
 	}
-	bp.sb.WriteString(fmt.Sprintf("%f", val))
+
+	if state.Buffered < len(bp.buffer) {
+		bp.buffer = bp.buffer[:state.Buffered]
+	}
+
+	bp.depth = openGroups(bp.buffer)
+	bp.currentIndent = state.Indent
 }
 
 func (bp *BasePrinter) IncIndent() {
@@ -110,23 +152,31 @@ func (bp *BasePrinter) DecIndent() {
 }
 
 func (bp *BasePrinter) PrintKeyword(keyword string, withIndent ...bool) {
-	if len(withIndent) > 0 && withIndent[0] {
-		bp.sb.WriteString(bp.makeIndent())
-	}
-	bp.sb.WriteString(bp.keywordFormatter(keyword))
+	bp.write(bp.keywordFormatter(keyword), withIndent)
 }
 
 func (bp *BasePrinter) PrintFunction(functionName string, withIndent ...bool) {
-	if len(withIndent) > 0 && withIndent[0] {
-		bp.sb.WriteString(bp.makeIndent())
-	}
-	bp.sb.WriteString(bp.functionFormatter(functionName))
+	bp.write(bp.functionFormatter(functionName), withIndent)
 }
 
 func (bp *BasePrinter) NewLine() {
+	if bp.depth > 0 {
+		bp.buffer = append(bp.buffer, token{kind: newLineToken})
+		return
+	}
+
 	bp.sb.WriteString("\n")
 }
 
 func (bp *BasePrinter) String() string {
-	return bp.sb.String()
+	if len(bp.buffer) == 0 {
+		return bp.sb.String()
+	}
+
+	// the groups that are still open are shown broken
+	sb := strings.Builder{}
+	sb.WriteString(bp.sb.String())
+	bp.layout(&sb)
+
+	return sb.String()
 }
@@ -0,0 +1,182 @@
+package printers
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// tabWidth The number of columns a tab counts for when measuring lines
+const tabWidth = 4
+
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	softBreakToken
+	newLineToken
+	beginToken
+	endToken
+)
+
+// token Something printed inside a group, text keeps the indent of the moment it was printed
+type token struct {
+	kind   tokenKind
+	text   string
+	indent string
+}
+
+// SetMaxWidth Lays groups out to fit in width columns, 0 prints every soft break as a line break
+func (bp *BasePrinter) SetMaxWidth(width int) {
+	bp.maxWidth = width
+}
+
+func (bp *BasePrinter) BeginGroup() {
+	if bp.maxWidth <= 0 {
+		return
+	}
+
+	bp.buffer = append(bp.buffer, token{kind: beginToken})
+	bp.depth++
+}
+
+func (bp *BasePrinter) EndGroup() {
+	if bp.maxWidth <= 0 || bp.depth == 0 {
+		return
+	}
+
+	bp.buffer = append(bp.buffer, token{kind: endToken})
+	bp.depth--
+
+	if bp.depth == 0 {
+		bp.layout(&bp.sb)
+		bp.buffer = nil
+	}
+}
+
+func (bp *BasePrinter) SoftBreak(flat string) {
+	if bp.maxWidth <= 0 || bp.depth == 0 {
+		bp.NewLine()
+		return
+	}
+
+	bp.buffer = append(bp.buffer, token{kind: softBreakToken, text: flat})
+}
+
+// layout Writes the buffer to sb, a group is printed flat when it fits on the rest of its line, or else with its
+// soft breaks as line breaks. Groups that have not ended do not fit
+func (bp *BasePrinter) layout(sb *strings.Builder) {
+	printed := sb.String()
+	column := textWidth(printed[strings.LastIndexByte(printed, '\n')+1:])
+
+	// flat is a stack with an entry for every open group
+	var flat []bool
+	skipIndent := false
+
+	for i, t := range bp.buffer {
+		broken := len(flat) == 0 || !flat[len(flat)-1]
+
+		switch t.kind {
+		case beginToken:
+			flat = append(flat, !broken || bp.fits(i, column))
+
+		case endToken:
+			if len(flat) > 0 {
+				flat = flat[:len(flat)-1]
+			}
+
+		case textToken:
+			// a line that was joined to the previous one does not start with an indent
+			if !skipIndent {
+				sb.WriteString(t.indent)
+				column += textWidth(t.indent)
+			}
+
+			sb.WriteString(t.text)
+			column += textWidth(t.text)
+			skipIndent = false
+
+		case softBreakToken:
+			if broken {
+				sb.WriteString("\n")
+				column = 0
+				skipIndent = false
+			} else {
+				sb.WriteString(t.text)
+				column += textWidth(t.text)
+				skipIndent = true
+			}
+
+		case newLineToken:
+			sb.WriteString("\n")
+			column = 0
+			skipIndent = false
+		}
+	}
+}
+
+// fits Reports whether the group that begins at buffer[i] fits on one line starting at column, together with the
+// text that follows it up to the next break
+func (bp *BasePrinter) fits(i, column int) bool {
+	width := column
+	depth := 0
+	ended := false
+	skipIndent := false
+
+	for _, t := range bp.buffer[i:] {
+		switch t.kind {
+		case beginToken:
+			depth++
+
+		case endToken:
+			depth--
+			ended = ended || depth == 0
+
+		case textToken:
+			if !skipIndent {
+				width += textWidth(t.indent)
+			}
+
+			width += textWidth(t.text)
+			skipIndent = false
+
+		case softBreakToken:
+			if ended {
+				return true
+			}
+
+			width += textWidth(t.text)
+			skipIndent = true
+
+		case newLineToken:
+			return ended
+		}
+
+		if width > bp.maxWidth {
+			return false
+		}
+	}
+
+	return ended
+}
+
+// openGroups Counts the groups in the buffer that have not ended
+func openGroups(buffer []token) int {
+	depth := 0
+
+	for _, t := range buffer {
+		switch t.kind {
+		case beginToken:
+			depth++
+
+		case endToken:
+			depth--
+		}
+	}
+
+	return depth
+}
+
+// textWidth Measures text in columns
+func textWidth(text string) int {
+	return utf8.RuneCountInString(text) + strings.Count(text, "\t")*(tabWidth-1)
+}
@@ -0,0 +1,63 @@
+package processors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dbreedt/pgPretty/helpers"
+	"github.com/dbreedt/pgPretty/interfaces"
+)
+
+// FormatterFactory Creates a new formatter for sql with the given named parameters, see
+// helpers.ProcessNamedParameters
+type FormatterFactory func(parameters map[int]string) interfaces.PgSqlFormatter
+
+// IdempotencyError Reports formatted sql that changes when it is formatted again
+type IdempotencyError struct {
+	// Line is the one based number of the first line that changed
+	Line int
+	// Formatted and Reformatted are that line before and after formatting it again
+	Formatted   string
+	Reformatted string
+}
+
+func (e *IdempotencyError) Error() string {
+	return fmt.Sprintf("formatting the output again changes line %d: %q became %q", e.Line, e.Formatted, e.Reformatted)
+}
+
+// CheckIdempotency Formats sql that was formatted before again with a new formatter from newFormatter, formatting is
+// expected to be a no-op the second time
+func CheckIdempotency(formatted string, newFormatter FormatterFactory, options Options) error {
+	workingSQL, detectedParameters := helpers.ProcessNamedParameters(formatted)
+	options.Parameters = detectedParameters
+
+	reformatted, err := ProcessSQLWithOptions(workingSQL, newFormatter(detectedParameters), options)
+	if err != nil {
+		return err
+	}
+
+	if reformatted == formatted {
+		return nil
+	}
+
+	formattedLines := strings.Split(formatted, "\n")
+	reformattedLines := strings.Split(reformatted, "\n")
+
+	for i := 0; ; i++ {
+		if i >= len(formattedLines) || i >= len(reformattedLines) || formattedLines[i] != reformattedLines[i] {
+			return &IdempotencyError{
+				Line:        i + 1,
+				Formatted:   line(formattedLines, i),
+				Reformatted: line(reformattedLines, i),
+			}
+		}
+	}
+}
+
+func line(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+
+	return ""
+}
@@ -5,16 +5,41 @@ import (
 	pg_query "github.com/pganalyze/pg_query_go"
 )
 
+// Options Changes what ProcessSQLWithOptions does on top of formatting
+type Options struct {
+	// Verify re-parses the formatted sql and fails instead of returning it when its parse tree differs from the
+	// original's, see Verify
+	Verify bool
+
+	// Parameters are the named parameters helpers.ProcessNamedParameters replaced in the sql, keyed by their
+	// location, Verify compares parameters by what they were written as
+	Parameters map[int]string
+}
+
 // ProcessSQL Uses the PostgresSQL parser to gain an AST. The AST is then used to start the formatting process
 //            by utilising the formatter and printer provided.
 func ProcessSQL(sql string, formatter interfaces.PgSqlFormatter) (string, error) {
+	return ProcessSQLWithOptions(sql, formatter, Options{})
+}
+
+func ProcessSQLWithOptions(sql string, formatter interfaces.PgSqlFormatter, options Options) (string, error) {
 	tree, err := pg_query.Parse(sql)
 	if err != nil {
 		return "", err
 	}
 
+	formatter.SetSource(sql)
+
 	for i := range tree.Statements {
-		formatter.PrintNode(tree.Statements[i])
+		if err := formatter.PrintNode(tree.Statements[i]); err != nil {
+			return "", err
+		}
+	}
+
+	if options.Verify {
+		if err := VerifyWithParameters(sql, options.Parameters, formatter.String()); err != nil {
+			return "", err
+		}
 	}
 
 	return formatter.String(), nil
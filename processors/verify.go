@@ -0,0 +1,363 @@
+package processors
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/dbreedt/pgPretty/helpers"
+	pg_query "github.com/pganalyze/pg_query_go"
+)
+
+// maxVerifyValueLength Longer values are cut short in a VerifyError
+const maxVerifyValueLength = 80
+
+// ignoredKeys Parse tree fields that formatting is expected to change
+var ignoredKeys = map[string]bool{
+	"location":      true,
+	"stmt_location": true,
+	"stmt_len":      true,
+}
+
+// identifierFields The fields of parse tree nodes that hold identifiers, a placeholder can stand in for those
+var identifierFields = map[string][]string{
+	"ColumnRef": {"fields"},
+	"RangeVar":  {"relname", "schemaname", "catalogname"},
+	"Alias":     {"aliasname", "colnames"},
+	"ResTarget": {"name"},
+}
+
+// VerifyError Reports formatted sql that does not parse into the same tree as the sql it was formatted from
+type VerifyError struct {
+	// Path leads to the first node that differs, e.g. [0].RawStmt.stmt.SelectStmt.whereClause
+	Path string
+	// Original and Formatted are the JSON of the node in both trees, empty when a tree does not have it
+	Original  string
+	Formatted string
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("formatting changed the parse tree at %s: %s became %s", e.Path, e.Original, e.Formatted)
+}
+
+// Verify Checks that the formatted sql parses into the same tree as the sql it was formatted from. Locations, the
+// placeholder style of parameters, the prefix of placeholders in the place of identifiers, the spelling of numeric
+// constants and the pg_catalog schema of built-in types are not compared, formatting options change those without
+// changing what the sql means
+func Verify(sql, formatted string) error {
+	return VerifyWithParameters(sql, nil, formatted)
+}
+
+// VerifyWithParameters Verify for sql that helpers.ProcessNamedParameters replaced the given named parameters in,
+// parameters are compared by what they were written as
+func VerifyWithParameters(sql string, parameters map[int]string, formatted string) error {
+	original, err := parseTree(sql)
+	if err != nil {
+		return err
+	}
+
+	// the formatted sql has its named parameters back, they have to go the same way as they did for sql
+	workingSQL, formattedParameters := helpers.ProcessNamedParameters(formatted)
+
+	result, err := parseTree(workingSQL)
+	if err != nil {
+		return fmt.Errorf("the formatted sql does not parse: %w", err)
+	}
+
+	normaliseParameters(original, parameters, result, formattedParameters)
+
+	original = normaliseTree(original, identifierParameters(sql, parameters))
+	result = normaliseTree(result, identifierParameters(workingSQL, formattedParameters))
+
+	if diff := compareTrees("", original, result); diff != nil {
+		return diff
+	}
+
+	return nil
+}
+
+func parseTree(sql string) (interface{}, error) {
+	jsonTree, err := pg_query.ParseToJSON(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree interface{}
+	if err := json.Unmarshal([]byte(jsonTree), &tree); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// parameter A ParamRef of a parse tree and the placeholder it was written as
+type parameter struct {
+	node        map[string]interface{}
+	placeholder string
+	location    int
+}
+
+// normaliseParameters Replaces the number of every parameter with what it stands for. A parameter that was printed
+// with a name is compared by its name, the others by their position, see helpers.NumberParameters
+func normaliseParameters(original interface{}, originalParameters map[int]string, formatted interface{}, formattedParameters map[int]string) {
+	originalStatements, _ := original.([]interface{})
+	formattedStatements, _ := formatted.([]interface{})
+
+	for i := 0; i < len(originalStatements) && i < len(formattedStatements); i++ {
+		o, originalNumbers := statementParameters(originalStatements[i], originalParameters)
+		f, formattedNumbers := statementParameters(formattedStatements[i], formattedParameters)
+
+		// the trees differ in more than their parameters, which compareTrees reports
+		if len(o) != len(f) {
+			continue
+		}
+
+		for j := range o {
+			delete(o[j].node, "number")
+			delete(f[j].node, "number")
+
+			if named(f[j].placeholder) {
+				o[j].node["name"] = parameterName(o[j].placeholder, originalNumbers[j])
+				f[j].node["name"] = parameterName(f[j].placeholder, formattedNumbers[j])
+			} else {
+				o[j].node["number"] = originalNumbers[j]
+				f[j].node["number"] = formattedNumbers[j]
+			}
+		}
+	}
+}
+
+// statementParameters Returns the parameters of a statement in the order they were written and their positions
+func statementParameters(stmt interface{}, parameters map[int]string) ([]parameter, []int) {
+	var found []parameter
+
+	walkParamRefs(stmt, func(node map[string]interface{}) {
+		number, _ := node["number"].(float64)
+		location, _ := node["location"].(float64)
+
+		p := parameter{node: node, placeholder: "?", location: int(location)}
+
+		if number > 0 {
+			p.placeholder = "$" + strconv.Itoa(int(number))
+		} else if placeholder, ok := parameters[p.location]; ok {
+			p.placeholder = placeholder
+		}
+
+		found = append(found, p)
+	})
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].location < found[j].location
+	})
+
+	placeholders := make([]string, len(found))
+	for i := range found {
+		placeholders[i] = found[i].placeholder
+	}
+
+	return found, helpers.NumberParameters(placeholders)
+}
+
+// parameterName Returns the name of a placeholder, the ones without a name are named after their position, e.g. p1
+func parameterName(placeholder string, number int) string {
+	if named(placeholder) {
+		return placeholder[1:]
+	}
+
+	return "p" + strconv.Itoa(number)
+}
+
+// named Checks whether a placeholder has a name, e.g. ?name or :name
+func named(placeholder string) bool {
+	return len(placeholder) > 1 && helpers.ParameterNumber(placeholder) == 0
+}
+
+func walkParamRefs(tree interface{}, visit func(node map[string]interface{})) {
+	switch t := tree.(type) {
+	case []interface{}:
+		for i := range t {
+			walkParamRefs(t[i], visit)
+		}
+
+	case map[string]interface{}:
+		for key, value := range t {
+			if node, ok := value.(map[string]interface{}); ok && key == "ParamRef" {
+				visit(node)
+				continue
+			}
+
+			walkParamRefs(value, visit)
+		}
+	}
+}
+
+// normaliseTree Rewrites the parts of a parse tree that formatting options may change without changing their meaning,
+// identifiers are the placeholders that were replaced with an identifier
+func normaliseTree(tree interface{}, identifiers map[string]bool) interface{} {
+	switch t := tree.(type) {
+	case []interface{}:
+		for i := range t {
+			t[i] = normaliseTree(t[i], identifiers)
+		}
+
+	case map[string]interface{}:
+		for key, value := range t {
+			if ignoredKeys[key] {
+				delete(t, key)
+				continue
+			}
+
+			node, _ := value.(map[string]interface{})
+
+			switch key {
+			case "Float":
+				if str, ok := node["str"].(string); ok {
+					node["str"] = helpers.NormaliseNumber(str)
+				}
+
+			case "TypeName":
+				if names, ok := node["names"].([]interface{}); ok && len(names) > 1 && isString(names[0], "pg_catalog") {
+					node["names"] = names[1:]
+				}
+			}
+
+			// placeholders in the place of an identifier are converted to the parameter style by name
+			for _, field := range identifierFields[key] {
+				normaliseIdentifiers(node, field, identifiers)
+			}
+
+			t[key] = normaliseTree(value, identifiers)
+		}
+	}
+
+	return tree
+}
+
+// normaliseIdentifiers Replaces the placeholders in a field that holds an identifier, or a list of them, with their
+// names
+func normaliseIdentifiers(node map[string]interface{}, field string, identifiers map[string]bool) {
+	if str, ok := node[field].(string); ok && identifiers[str] {
+		node[field] = helpers.PlaceholderName(str)
+	}
+
+	list, _ := node[field].([]interface{})
+
+	for _, item := range list {
+		n, _ := item.(map[string]interface{})
+		s, _ := n["String"].(map[string]interface{})
+
+		if str, ok := s["str"].(string); ok && identifiers[str] {
+			s["str"] = helpers.PlaceholderName(str)
+		}
+	}
+}
+
+// identifierParameters Returns the placeholders that helpers.ProcessNamedParameters replaced with an identifier in sql
+func identifierParameters(sql string, parameters map[int]string) map[string]bool {
+	identifiers := make(map[string]bool)
+
+	for offset, placeholder := range parameters {
+		if offset < len(sql) && sql[offset] == '"' {
+			identifiers[placeholder] = true
+		}
+	}
+
+	return identifiers
+}
+
+func isString(node interface{}, str string) bool {
+	s, _ := node.(map[string]interface{})["String"].(map[string]interface{})
+
+	return s != nil && s["str"] == str
+}
+
+// compareTrees Returns where the trees first differ, or nil when they are the same
+func compareTrees(path string, original, formatted interface{}) *VerifyError {
+	switch o := original.(type) {
+	case map[string]interface{}:
+		f, ok := formatted.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		for _, key := range sortedKeys(o, f) {
+			if diff := compareTrees(path+"."+key, o[key], f[key]); diff != nil {
+				return diff
+			}
+		}
+
+		return nil
+
+	case []interface{}:
+		f, ok := formatted.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(o) || i < len(f); i++ {
+			var oi, fi interface{}
+
+			if i < len(o) {
+				oi = o[i]
+			}
+
+			if i < len(f) {
+				fi = f[i]
+			}
+
+			if diff := compareTrees(path+"["+strconv.Itoa(i)+"]", oi, fi); diff != nil {
+				return diff
+			}
+		}
+
+		return nil
+
+	default:
+		if reflect.DeepEqual(original, formatted) {
+			return nil
+		}
+	}
+
+	return &VerifyError{
+		Path:      path,
+		Original:  verifyValue(original),
+		Formatted: verifyValue(formatted),
+	}
+}
+
+func sortedKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for key := range a {
+		keys = append(keys, key)
+	}
+
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func verifyValue(value interface{}) string {
+	if value == nil {
+		return "nothing"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	if len(data) > maxVerifyValueLength {
+		return string(data[:maxVerifyValueLength]) + "..."
+	}
+
+	return string(data)
+}
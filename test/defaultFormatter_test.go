@@ -9,17 +9,45 @@ import (
 	"text/template"
 
 	"github.com/dbreedt/pgPretty/formatters"
+	"github.com/dbreedt/pgPretty/helpers"
+	"github.com/dbreedt/pgPretty/interfaces"
 	"github.com/dbreedt/pgPretty/printers"
 	"github.com/dbreedt/pgPretty/processors"
 	"github.com/kylelemons/godebug/pretty"
 )
 
 const (
-	baseDir = "./sql/defaultFormatter/"
-	filter  = "" // for debugging purposes: add the name of the sql file you want to test to avoid all the others from being tested
+	baseDir    = "./sql/defaultFormatter/"
+	optionsDir = "./sql/options/"
+	filter     = "" // for debugging purposes: add the name of the sql file you want to test to avoid all the others from being tested
 )
 
+// optionSets The formatter options under test, each set has its own input and output directories in optionsDir
+var optionSets = map[string]formatters.FormatterOptions{
+	"alignValues":          {AlignValues: true},
+	"keepParentheses":      {KeepParentheses: true},
+	"standardTypeNames":    {StandardTypeNames: true},
+	"standardCasts":        {StandardCasts: true},
+	"dollarQuoteLength":    {DollarQuoteLength: 10},
+	"normaliseNumbers":     {NormaliseNumbers: true},
+	"verbatimFallback":     {VerbatimFallback: true},
+	"blankLines":           {BlankLines: 2},
+	"maxWidth":             {MaxWidth: 50},
+	"positionalParameters": {ParameterStyle: formatters.ParameterStylePositional},
+	"colonParameters":      {ParameterStyle: formatters.ParameterStyleColon},
+}
+
 func TestSqlFiles(t *testing.T) {
+	testSqlFiles(t, baseDir, formatters.FormatterOptions{})
+}
+
+func TestSqlFilesWithOptions(t *testing.T) {
+	for name, options := range optionSets {
+		testSqlFiles(t, path.Join(optionsDir, name), options)
+	}
+}
+
+func testSqlFiles(t *testing.T, baseDir string, options formatters.FormatterOptions) {
 	files, err := ioutil.ReadDir(path.Join(baseDir, "input"))
 	if err != nil {
 		t.Fatal(err)
@@ -30,16 +58,18 @@ func TestSqlFiles(t *testing.T) {
 			continue
 		}
 
-		spacePrinter := printers.NewBasePrinter(false, true, true, 2)
-		dfSpace := formatters.NewDefaultFormatter(spacePrinter)
-
 		srcData, err := ioutil.ReadFile(path.Join(baseDir, "input", file.Name()))
 		if err != nil {
 			t.Log("intput", file.Name(), err)
 			t.Fail()
 		}
 
-		sqlOut, err := processors.ProcessSQL(string(srcData), dfSpace)
+		workingSQL, detectedParameters := helpers.ProcessNamedParameters(string(srcData))
+
+		spacePrinter := printers.NewBasePrinter(false, true, true, 2)
+		dfSpace := formatters.NewDefaultFormatterWithOptions(spacePrinter, detectedParameters, options)
+
+		sqlOut, err := processors.ProcessSQL(workingSQL, dfSpace)
 		if err != nil {
 			t.Log(file.Name(), err)
 			t.Fail()
@@ -62,16 +92,27 @@ func TestSqlFiles(t *testing.T) {
 			t.Fail()
 		}
 
+		if err := processors.VerifyWithParameters(workingSQL, detectedParameters, sqlOut); err != nil {
+			t.Log("SPACE", file.Name(), err)
+			t.Fail()
+		}
+
+		if err := processors.CheckIdempotency(sqlExp, newFormatter(false, true, true, 2, options), processors.Options{}); err != nil {
+			t.Log("SPACE", file.Name(), "EXPECTED SQL IS NOT A FIXED POINT", err)
+			t.Fail()
+		}
+
 		// Run the expected sql through the postgres parser to ensure it is valid sql
-		_, err = processors.ProcessSQL(sqlExp, dfSpace)
+		expWorkingSQL, _ := helpers.ProcessNamedParameters(sqlExp)
+		_, err = processors.ProcessSQL(expWorkingSQL, dfSpace)
 		if err != nil {
 			t.Fatal(file.Name(), "EXPECTED SQL IS INVALID", err)
 		}
 
 		tabPrinter := printers.NewBasePrinter(true, false, false, 1)
-		dfTab := formatters.NewDefaultFormatter(tabPrinter)
+		dfTab := formatters.NewDefaultFormatterWithOptions(tabPrinter, detectedParameters, options)
 
-		sqlOut, err = processors.ProcessSQL(string(srcData), dfTab)
+		sqlOut, err = processors.ProcessSQL(workingSQL, dfTab)
 		if err != nil {
 			t.Log(file.Name(), err)
 			t.Fail()
@@ -87,6 +128,25 @@ func TestSqlFiles(t *testing.T) {
 			t.Log(pretty.Compare(strings.Split(sqlOut, "\n"), strings.Split(sqlExp, "\n")))
 			t.Fail()
 		}
+
+		if err := processors.VerifyWithParameters(workingSQL, detectedParameters, sqlOut); err != nil {
+			t.Log("TAB", file.Name(), err)
+			t.Fail()
+		}
+
+		if err := processors.CheckIdempotency(sqlExp, newFormatter(true, false, false, 1, options), processors.Options{}); err != nil {
+			t.Log("TAB", file.Name(), "EXPECTED SQL IS NOT A FIXED POINT", err)
+			t.Fail()
+		}
+	}
+}
+
+// newFormatter Creates formatters with the same printer configuration and options for every pass
+func newFormatter(useTabs, upperKeywords, upperFunctions bool, indent int, options formatters.FormatterOptions) processors.FormatterFactory {
+	return func(parameters map[int]string) interfaces.PgSqlFormatter {
+		printer := printers.NewBasePrinter(useTabs, upperKeywords, upperFunctions, indent)
+
+		return formatters.NewDefaultFormatterWithOptions(printer, parameters, options)
 	}
 }
 
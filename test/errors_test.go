@@ -0,0 +1,119 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dbreedt/pgPretty/formatters"
+	"github.com/dbreedt/pgPretty/helpers"
+	"github.com/dbreedt/pgPretty/printers"
+	"github.com/dbreedt/pgPretty/processors"
+)
+
+func TestUnsupportedError(t *testing.T) {
+	sql := "select 1;\nselect *\nfrom t\nwhere t.a is distinct from t.b"
+
+	_, err := processors.ProcessSQL(sql, formatters.NewDefaultFormatter(printers.NewDefaultSpacePrinter()))
+
+	var ue *formatters.UnsupportedError
+	if !errors.As(err, &ue) {
+		t.Fatalf("expected an UnsupportedError, got %v", err)
+	}
+
+	expected := formatters.UnsupportedError{
+		NodeType:  "A_Expr",
+		Detail:    ue.Detail,
+		Statement: 1,
+		Offset:    32,
+		Line:      4,
+		Column:    7,
+	}
+
+	if *ue != expected {
+		t.Errorf("expected %+v, got %+v", expected, *ue)
+	}
+}
+
+func TestUnsupportedStatementIsLeftOut(t *testing.T) {
+	formatter := formatters.NewDefaultFormatter(printers.NewDefaultSpacePrinter())
+
+	if _, err := processors.ProcessSQL("select a from t where a is distinct from b", formatter); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if formatter.String() != "" {
+		t.Errorf("expected the failed statement to be left out, got %q", formatter.String())
+	}
+}
+
+func TestVerbatimRegions(t *testing.T) {
+	sql := "select 1;\nselect *\nfrom t\nwhere t.a is distinct from t.b\nand t.c = 1"
+
+	formatter := formatters.NewDefaultFormatterWithOptions(printers.NewDefaultSpacePrinter(), nil, formatters.FormatterOptions{VerbatimFallback: true})
+
+	if _, err := processors.ProcessSQL(sql, formatter); err != nil {
+		t.Fatal(err)
+	}
+
+	regions := formatter.VerbatimRegions()
+	if len(regions) != 1 {
+		t.Fatalf("expected 1 verbatim region, got %d", len(regions))
+	}
+
+	if r := regions[0]; r.Offset != 32 || r.Length != 24 || r.Line != 4 || r.Column != 7 || r.Cause.NodeType != "A_Expr" {
+		t.Errorf("unexpected verbatim region %+v", r)
+	}
+}
+
+func TestPositionsAreInTheWrittenSql(t *testing.T) {
+	sql := "select ?longname, a is distinct from b from ?Table"
+	workingSQL, detectedParameters := helpers.ProcessNamedParameters(sql)
+
+	formatter := formatters.NewDefaultFormatterWithParameters(printers.NewDefaultSpacePrinter(), detectedParameters)
+
+	var ue *formatters.UnsupportedError
+	if _, err := processors.ProcessSQL(workingSQL, formatter); !errors.As(err, &ue) {
+		t.Fatalf("expected an UnsupportedError, got %v", err)
+	}
+
+	if ue.Offset != 18 || ue.Line != 1 || ue.Column != 19 {
+		t.Errorf("expected offset 18 at column 19, got %+v", *ue)
+	}
+
+	formatter = formatters.NewDefaultFormatterWithOptions(printers.NewDefaultSpacePrinter(), detectedParameters, formatters.FormatterOptions{VerbatimFallback: true})
+
+	if _, err := processors.ProcessSQL(workingSQL, formatter); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := formatter.VerbatimRegions()[0]; sql[r.Offset:r.Offset+r.Length] != "a is distinct from b" || r.Column != 19 {
+		t.Errorf("unexpected verbatim region %+v", r)
+	}
+}
+
+func TestBareParametersCanNotBeReordered(t *testing.T) {
+	for _, sql := range []string{
+		"select $2, $1, $2 from t",
+		"select ?a, ?b, ?a from t",
+	} {
+		workingSQL, detectedParameters := helpers.ProcessNamedParameters(sql)
+		formatter := formatters.NewDefaultFormatterWithOptions(printers.NewDefaultSpacePrinter(), detectedParameters, formatters.FormatterOptions{ParameterStyle: formatters.ParameterStyleBare})
+
+		_, err := processors.ProcessSQL(workingSQL, formatter)
+
+		var ue *formatters.UnsupportedError
+		if !errors.As(err, &ue) || ue.NodeType != "ParamRef" {
+			t.Errorf("%s: expected a ParamRef UnsupportedError, got %v", sql, err)
+		}
+	}
+}
+
+func TestIdentifierParametersCanNotBeNumbered(t *testing.T) {
+	workingSQL, detectedParameters := helpers.ProcessNamedParameters("select id from ?TableName where id = ?id")
+	formatter := formatters.NewDefaultFormatterWithOptions(printers.NewDefaultSpacePrinter(), detectedParameters, formatters.FormatterOptions{ParameterStyle: formatters.ParameterStylePositional})
+
+	var ue *formatters.UnsupportedError
+	if _, err := processors.ProcessSQL(workingSQL, formatter); !errors.As(err, &ue) {
+		t.Fatalf("expected an UnsupportedError, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dbreedt/pgPretty/formatters"
+	"github.com/dbreedt/pgPretty/interfaces"
+	"github.com/dbreedt/pgPretty/printers"
+	"github.com/dbreedt/pgPretty/processors"
+)
+
+func TestCheckIdempotencyReportsFirstChangedLine(t *testing.T) {
+	newFormatter := func(parameters map[int]string) interfaces.PgSqlFormatter {
+		return &brokenFormatter{sql: "select\n  a\nfrom\n  u;"}
+	}
+
+	err := processors.CheckIdempotency("select\n  a\nfrom\n  t;", newFormatter, processors.Options{})
+
+	var ie *processors.IdempotencyError
+	if !errors.As(err, &ie) {
+		t.Fatalf("expected an IdempotencyError, got %v", err)
+	}
+
+	expected := processors.IdempotencyError{Line: 4, Formatted: "  t;", Reformatted: "  u;"}
+	if *ie != expected {
+		t.Errorf("expected %+v, got %+v", expected, *ie)
+	}
+}
+
+func TestMaxWidthWithCommentsIsIdempotent(t *testing.T) {
+	options := formatters.FormatterOptions{MaxWidth: 80}
+
+	for _, sql := range []string{
+		"select 1 -- c\n;",
+		"select a, -- first\n  b\nfrom t\nwhere x = 1\n  -- only this year\n  and y = 2",
+	} {
+		formatter := formatters.NewDefaultFormatterWithOptions(printers.NewDefaultSpacePrinter(), nil, options)
+
+		formatted, err := processors.ProcessSQL(sql, formatter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := processors.CheckIdempotency(formatted, newFormatter(false, false, false, 2, options), processors.Options{}); err != nil {
+			t.Errorf("%q: %v", sql, err)
+		}
+	}
+}
@@ -51,6 +51,62 @@ type Keywords struct {
 	Filter    string
 	Having    string
 	Ilike     string
+	Insert    string
+	Values    string
+	Default   string
+	Conflict  string
+	Do        string
+	Nothing   string
+	Update    string
+	Set       string
+	Returning string
+	Current   string
+	Of        string
+	Delete    string
+	Only      string
+	Using     string
+	Union     string
+	Intersect string
+	Except    string
+	Window    string
+	Rows      string
+	Range     string
+	Unbounded string
+	Preceding string
+	Following string
+	Row       string
+	When      string
+	Then      string
+	Else      string
+	End       string
+	Recursive string
+	Natural   string
+	Offset    string
+	Fetch     string
+	First     string
+	For       string
+	No        string
+	Key       string
+	Share     string
+	Nowait    string
+	Skip      string
+	Locked    string
+	Grouping  string
+	Sets      string
+	Rollup    string
+	Cube      string
+	Cast      string
+	Time      string
+	Zone      string
+	Year      string
+	Month     string
+	Day       string
+	Hour      string
+	Minute    string
+	Second    string
+	To        string
+	Setof     string
+	Type      string
 }
 
 func NewKeywords(upperCaseKeywords, upperCaseFunctions bool) Keywords {
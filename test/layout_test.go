@@ -0,0 +1,116 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/dbreedt/pgPretty/printers"
+)
+
+// printCall Prints f(a, b) as a group that breaks between its arguments
+func printCall(printer *printers.BasePrinter, name string, args ...string) {
+	printer.BeginGroup()
+	printer.PrintString(name + "(")
+	printer.IncIndent()
+
+	for i, arg := range args {
+		if i == 0 {
+			printer.SoftBreak("")
+		} else {
+			printer.PrintString(",")
+			printer.SoftBreak(" ")
+		}
+
+		printer.PrintString(arg, true)
+	}
+
+	printer.DecIndent()
+	printer.SoftBreak("")
+	printer.PrintString(")", true)
+	printer.EndGroup()
+}
+
+func TestGroupsFitOrBreak(t *testing.T) {
+	tests := []struct {
+		width    int
+		expected string
+	}{
+		{width: 0, expected: "f(\n  a,\n  g(\n    b,\n    c\n  )\n)"},
+		{width: 40, expected: "f(a, g(b, c))"},
+		{width: 13, expected: "f(a, g(b, c))"},
+		{width: 12, expected: "f(\n  a,\n  g(b, c)\n)"},
+		{width: 5, expected: "f(\n  a,\n  g(\n    b,\n    c\n  )\n)"},
+	}
+
+	for _, test := range tests {
+		printer := printers.NewBasePrinter(false, false, false, 2)
+		printer.SetMaxWidth(test.width)
+
+		printer.BeginGroup()
+		printer.PrintString("f(")
+		printer.IncIndent()
+		printer.SoftBreak("")
+		printer.PrintString("a,", true)
+		printer.SoftBreak(" ")
+		printer.PrintString("", true)
+		printCall(printer, "g", "b", "c")
+		printer.DecIndent()
+		printer.SoftBreak("")
+		printer.PrintString(")", true)
+		printer.EndGroup()
+
+		if actual := printer.String(); actual != test.expected {
+			t.Errorf("width %d: expected %q, got %q", test.width, test.expected, actual)
+		}
+	}
+}
+
+func TestGroupsCountTheTextAfterThem(t *testing.T) {
+	printer := printers.NewBasePrinter(false, false, false, 2)
+	printer.SetMaxWidth(12)
+
+	printer.BeginGroup()
+	printer.PrintString("x = ")
+	printCall(printer, "f", "a")
+	printer.PrintString(" + 1234")
+	printer.EndGroup()
+
+	// x = f(a) fits, but not with the text that follows it up to the next break
+	if expected, actual := "x = f(\n  a\n) + 1234", printer.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestGroupsWithLineBreaksDoNotFit(t *testing.T) {
+	printer := printers.NewBasePrinter(true, false, false, 1)
+	printer.SetMaxWidth(80)
+
+	printer.BeginGroup()
+	printer.PrintString("select")
+	printer.SoftBreak(" ")
+	printer.PrintString("a", true)
+	printer.NewLine()
+	printer.PrintString("-- b", true)
+	printer.EndGroup()
+
+	if expected, actual := "select\na\n-- b", printer.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestRestoreIntoAGroup(t *testing.T) {
+	printer := printers.NewBasePrinter(false, false, false, 2)
+	printer.SetMaxWidth(80)
+
+	printer.BeginGroup()
+	printer.PrintString("select")
+	printer.SoftBreak(" ")
+	state := printer.State()
+	printer.PrintString("a very long target list that does not fit on the line of the select keyword")
+	printer.Restore(state)
+	printer.PrintString("b")
+	printer.EndGroup()
+
+	if expected, actual := "select b", printer.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,2 @@
+select s.id from (with recursive r(id) as (select 1 union all select id + 1 from r where id < 3) select id from r) s
+where s.id in (with k as (select id from tab7) select id from k)
\ No newline at end of file
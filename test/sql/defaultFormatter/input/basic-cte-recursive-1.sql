@@ -0,0 +1 @@
+with recursive tree(id, parent_id, depth) as (select id, parent_id, 0 from nodes where parent_id is null union all select n.id, n.parent_id, t.depth + 1 from nodes n join tree t on t.id = n.parent_id), moved as (delete from archive a where a.id in (select id from tree) returning a.id) select * from tree t join moved m on m.id = t.id order by t.depth
\ No newline at end of file
@@ -0,0 +1,3 @@
+delete from only some_schema.tab7 t7
+where t7.created < now() and (t7.archived or t7.deleted)
+returning t7.id
\ No newline at end of file
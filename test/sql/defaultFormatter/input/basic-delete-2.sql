@@ -0,0 +1,8 @@
+with stale as (
+    select id from tab8 where updated < '2020-01-01'
+)
+delete from tab7 as t7
+using stale s
+join tab9 t9 on t9.id = s.id, tab10 t10
+where t7.id = s.id
+and t10.id = t9.id
\ No newline at end of file
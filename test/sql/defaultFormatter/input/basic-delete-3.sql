@@ -0,0 +1 @@
+delete from tab7
\ No newline at end of file
@@ -0,0 +1,3 @@
+insert into some_schema.tab7 (id, name, score)
+values (1, 'bob', 42), (2, 'alice', null), (3, 'eve', default)
+returning id, name as person
\ No newline at end of file
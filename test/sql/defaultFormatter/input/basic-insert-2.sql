@@ -0,0 +1,7 @@
+with data as (
+    select * from tab
+)
+insert into tab7 (id, name)
+select d.id, d.name
+from data d
+where d.active
\ No newline at end of file
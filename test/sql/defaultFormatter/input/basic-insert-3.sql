@@ -0,0 +1,6 @@
+insert into tab7 as t (id, qty, tags[1])
+values (?, ?, 'new')
+on conflict (id) do update
+set qty = t.qty + excluded.qty, tags[1] = excluded.tags[1]
+where not t.locked
+returning *
\ No newline at end of file
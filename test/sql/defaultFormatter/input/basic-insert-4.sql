@@ -0,0 +1 @@
+insert into tab7 (id) values (1) on conflict (lower(name), (id + 1)) where active do nothing
\ No newline at end of file
@@ -0,0 +1 @@
+insert into tab7 default values
\ No newline at end of file
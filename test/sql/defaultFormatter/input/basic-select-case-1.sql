@@ -0,0 +1,8 @@
+select t7.id,
+case when t7.score > 90 then 'a' when t7.score > 80 and t7.bonus then 'b' else 'c' end as grade,
+case t7.status when 1 then 'new' when 2 then case when t7.late then 'late' else 'on time' end end status,
+ifnull(case when t7.x then t7.y end, 0) as val,
+sum(case when t7.ok then 1 else 0 end) oks
+from tab7 t7
+where case when t7.kind = 'x' then t7.a else t7.b end > 10
+and t7.name = ifnull(t7.alias, 'none')
\ No newline at end of file
@@ -0,0 +1,3 @@
+select coalesce(a, b), greatest(a, b, 0), least(a, coalesce(b, case when c then 1 else 2 end))
+from t
+where coalesce(t.a, t.b) > 0
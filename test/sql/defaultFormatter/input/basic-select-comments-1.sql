@@ -0,0 +1,14 @@
+-- monthly revenue per account
+-- owner: billing
+select a.id, -- the account
+       /* total */ sum(i.amount) as total,
+       count(*) -- number of invoices
+from accounts a -- all accounts
+join invoices i on i.account_id = a.id
+where i.paid
+  -- only this year
+  and i.year = 2020 /* hard coded */
+group by a.id;
+
+/* trailing
+   block */
@@ -0,0 +1,11 @@
+with recent as ( -- last week
+  select * from events /* all */ where ts > now() - interval '7 days'
+)
+select id, ifnull(a, -- fallback
+  b) from recent where id > /* two */ 2 -- ids
+order by id desc -- newest first
+;
+select 1; -- one
+insert into t (a, b) -- cols
+values (1, 2), -- row 1
+  (3, 4);
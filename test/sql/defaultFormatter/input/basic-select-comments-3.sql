@@ -0,0 +1,6 @@
+select a /* inline */ + b, c
+from t
+where x = /* why */ 1
+  and y /* before and */ and z;
+select 2
+-- end
\ No newline at end of file
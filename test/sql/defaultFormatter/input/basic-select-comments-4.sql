@@ -0,0 +1,8 @@
+select a, -- first
+  (select max(b /* in */) from u /* last */) as m, -- second
+  d -- third
+from t
+where x = coalesce(1, 2 /* two */) -- fourth
+  and y = (select 1
+    /* own line */
+  ) -- fifth
\ No newline at end of file
@@ -0,0 +1 @@
+select region, product, grouping(region, product) as level, sum(amount) as total from sales group by grouping sets ((region, product), (region), ()), rollup(region, product), cube(region, (product, channel)), grouping sets (rollup(year, month), cube(region, product))
\ No newline at end of file
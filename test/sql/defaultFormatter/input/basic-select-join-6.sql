@@ -0,0 +1 @@
+select * from a natural join b natural left join c join d using (id, name) left join (e join f using (id)) on f.id = a.id join (g natural join h) as gh on gh.id = a.id cross join (i join j on j.id = i.id)
\ No newline at end of file
@@ -0,0 +1 @@
+select a from t where a not like 'x%' and b not ilike 'y%' and c like 'z%' and d ilike 'w%'
\ No newline at end of file
@@ -0,0 +1 @@
+select id from jobs where state = 'queued' order by created_at limit 10 offset 20 for update skip locked
\ No newline at end of file
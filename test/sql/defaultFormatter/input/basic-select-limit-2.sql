@@ -0,0 +1 @@
+select * from jobs j join queues q on q.id = j.queue_id order by j.id offset 5 fetch first 3 rows only for no key update of j nowait for key share of q
\ No newline at end of file
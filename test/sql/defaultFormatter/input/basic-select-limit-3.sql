@@ -0,0 +1 @@
+select * from t where t.id > 100 limit all
\ No newline at end of file
@@ -0,0 +1,3 @@
+select fetch_next, 'fetch' as label from jobs where state <> 'limit' order by id limit 5;
+select id as "fetch" from jobs -- limit
+offset 2 fetch next (3 + 1) rows only
\ No newline at end of file
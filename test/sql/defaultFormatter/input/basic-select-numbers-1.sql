@@ -0,0 +1 @@
+select 1e-10, 3.14159265358979, 123456789012345678901234567890.123, 1.50, 1E5, 007, -2.5, 9223372036854775808, 1.0, .5, 00.500E+03, 2.5e-07 from t where price > 10.00
\ No newline at end of file
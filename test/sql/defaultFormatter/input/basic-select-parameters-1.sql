@@ -0,0 +1 @@
+select * from orders o where o.customer_id = $1 and o.created_at >= $2 and o.status <> $3 and o.assigned_to = $1 limit $4
\ No newline at end of file
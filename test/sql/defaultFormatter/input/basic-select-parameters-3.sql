@@ -0,0 +1,3 @@
+select ?Columns2, e'it\'s ?not', $$ ?nope $$, $tag$ :nope $tag$ -- ?comment
+from t /* ?x /* nested ?y */ */
+where a = ? and b = ?name and c like '%?%' and d = ?0 and e = :sqlx and f = arr[1:n] and g = ?name and h = $1 and now()::date > i
\ No newline at end of file
@@ -0,0 +1 @@
+select ?Columns, t.?IdColumn, count(*) as ?CountAlias from ?TableName t join ?Schema.orders o on o.?ForeignKey = t.id where t.tenant = ?tenantID and t.kind like '%?%' group by ?Columns
\ No newline at end of file
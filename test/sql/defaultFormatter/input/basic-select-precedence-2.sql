@@ -0,0 +1 @@
+select @ -1, @ -1.5, @ - x, |/ -4::float, ~ -a from t where a and not d and (b and c) or (e or f)
\ No newline at end of file
@@ -0,0 +1 @@
+select x::"char", y::"MyType", z::public."Weird Type", a::pg_catalog."char", b::pg_catalog.int4, c::timestamptz(3), d::interval day to second, e::"select"[]
\ No newline at end of file
@@ -0,0 +1 @@
+select x::"timestamptz", y::pg_catalog.int4, z::int, w::timestamptz(3), v::timestamp(2) with time zone, u::pg_catalog.timestamptz, t::"interval", q::"pg_catalog".int8, 't'::pg_catalog.bool
\ No newline at end of file
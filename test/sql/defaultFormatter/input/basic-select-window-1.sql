@@ -0,0 +1,8 @@
+select id, row_number() over (partition by t7.grp order by t7.created desc) as rn,
+sum(t7.qty) over w as running,
+avg(t7.qty) over (w rows between 3 preceding and current row),
+count(*) filter (where t7.ok) over (partition by t7.grp, t7.sub_grp, t7.region order by t7.created, t7.id range between unbounded preceding and unbounded following) cnt,
+string_agg(t7.name, ',' order by t7.name) as all_names
+from tab7 t7
+window w as (partition by t7.grp order by t7.created rows unbounded preceding), w2 as (w)
+order by rn
\ No newline at end of file
@@ -0,0 +1,7 @@
+select id, name from tab7
+union all
+select id, name from tab8 where active
+union
+select id, name from tab9
+order by name desc
+limit 10
\ No newline at end of file
@@ -0,0 +1,5 @@
+(select id from tab7 union select id from tab8)
+intersect
+(select id from tab9 order by id limit 5)
+except all
+select id from tab10
\ No newline at end of file
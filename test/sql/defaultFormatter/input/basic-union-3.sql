@@ -0,0 +1,8 @@
+with a as (
+    select id from tab7
+    intersect
+    select id from tab8
+)
+select id from a
+except
+(select id from tab9 except select id from tab10)
\ No newline at end of file
@@ -0,0 +1,2 @@
+(with x as (select id from tab7) select id from x) union select id from tab8;
+(select id from tab7) union all (select (id) from tab8) except select id from tab9
\ No newline at end of file
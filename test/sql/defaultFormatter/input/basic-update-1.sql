@@ -0,0 +1,4 @@
+update some_schema.tab7 t7
+set name = 'bob', score = t7.score + 1, updated = now()
+where t7.id = ?
+returning t7.id, t7.score as new_score
\ No newline at end of file
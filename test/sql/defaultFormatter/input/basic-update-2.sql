@@ -0,0 +1,2 @@
+update tab7 t7 set (qty, total) = (select sum(t8.qty), sum(t8.total) from tab8 t8 where t8.tab7_id = t7.id), tags[2] = 'x', (a, b) = (1, 2)
+where t7.id = (select max(id) from tab7)
\ No newline at end of file
@@ -0,0 +1,8 @@
+with data as (
+    select * from tab
+)
+update tab7 t7
+set qty = d.qty
+from data d
+join tab8 t8 on t8.id = d.id
+where d.id = t7.id and t8.active
\ No newline at end of file
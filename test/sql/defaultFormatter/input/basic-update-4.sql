@@ -0,0 +1 @@
+update tab7 set active = 1 where current of some_cursor
\ No newline at end of file
@@ -0,0 +1 @@
+update orders set status = :status, updated_at = now()::timestamp where id = :id returning id
\ No newline at end of file
@@ -0,0 +1,3 @@
+values (1, 'one', now()), (2, 'two', null), (3, 'three', now())
+order by 1 desc
+limit 2
\ No newline at end of file
@@ -0,0 +1,3 @@
+select v.id, v.name, t7.score
+from (values (1, 'bob'), (22, 'alice')) as v(id, name)
+join tab7 t7 on t7.name = v.name
\ No newline at end of file
@@ -0,0 +1,6 @@
+with data as (
+    values (1, 2), (3, 4)
+)
+select * from data
+union all
+values (5, 6)
\ No newline at end of file
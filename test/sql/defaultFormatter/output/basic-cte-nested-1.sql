@@ -0,0 +1,33 @@
+{{ .Select}}
+{{ .Ws}}s.id
+{{ .From}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}{{ .With}} {{ .Recursive}} r(id) {{ .As}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}1
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Union}} {{ .All}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}id + 1
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}r
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}id < 3
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}r
+{{ .Ws}}) s
+{{ .Where}}
+{{ .Ws}}s.id {{ .In}}(
+{{ .Ws}}{{ .Ws}}{{ .With}} k {{ .As}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}tab7
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}k
+{{ .Ws}});
\ No newline at end of file
@@ -0,0 +1,44 @@
+{{ .With}} {{ .Recursive}} tree(id, parent_id, depth) {{ .As}} (
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id,
+{{ .Ws}}{{ .Ws}}parent_id,
+{{ .Ws}}{{ .Ws}}0
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}nodes
+{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}parent_id {{ .Is}} {{ .Null}}
+{{ .Ws}}{{ .Union}} {{ .All}}
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}n.id,
+{{ .Ws}}{{ .Ws}}n.parent_id,
+{{ .Ws}}{{ .Ws}}t.depth + 1
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}nodes n
+{{ .Ws}}{{ .Join}}
+{{ .Ws}}{{ .Ws}}tree t
+{{ .Ws}}{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}t.id = n.parent_id
+),
+moved {{ .As}} (
+{{ .Ws}}{{ .Delete}} {{ .From}}
+{{ .Ws}}{{ .Ws}}archive a
+{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}a.id {{ .In}}(
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}tree
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}}{{ .Returning}}
+{{ .Ws}}{{ .Ws}}a.id
+)
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}tree t
+{{ .Join}}
+{{ .Ws}}moved m
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}m.id = t.id
+{{ .Order}} {{ .By}}
+{{ .Ws}}t.depth;
\ No newline at end of file
@@ -7,4 +7,4 @@
 {{ .Select}}
 {{ .Ws}}*
 {{ .From}}
-{{ .Ws}}data
\ No newline at end of file
+{{ .Ws}}data;
\ No newline at end of file
@@ -0,0 +1,10 @@
+{{ .Delete}} {{ .From}}
+{{ .Ws}}{{ .Only}} some_schema.tab7 t7
+{{ .Where}}
+{{ .Ws}}t7.created < {{ .Fn "now"}}()
+{{ .Ws}}{{ .And}} (
+{{ .Ws}}{{ .Ws}}t7.archived
+{{ .Ws}}{{ .Ws}}{{ .Or}} t7.deleted
+{{ .Ws}})
+{{ .Returning}}
+{{ .Ws}}t7.id;
\ No newline at end of file
@@ -0,0 +1,20 @@
+{{ .With}} stale {{ .As}} (
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab8
+{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}updated < '2020-01-01'
+)
+{{ .Delete}} {{ .From}}
+{{ .Ws}}tab7 t7
+{{ .Using}}
+{{ .Ws}}stale s
+{{ .Join}}
+{{ .Ws}}tab9 t9
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}t9.id = s.id,
+{{ .Ws}}tab10 t10
+{{ .Where}}
+{{ .Ws}}t7.id = s.id
+{{ .Ws}}{{ .And}} t10.id = t9.id;
\ No newline at end of file
@@ -0,0 +1,2 @@
+{{ .Delete}} {{ .From}}
+{{ .Ws}}tab7;
\ No newline at end of file
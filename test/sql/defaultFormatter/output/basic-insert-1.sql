@@ -0,0 +1,9 @@
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}some_schema.tab7 (id, name, score)
+{{ .Values}}
+{{ .Ws}}(1, 'bob', 42),
+{{ .Ws}}(2, 'alice', {{ .Null}}),
+{{ .Ws}}(3, 'eve', {{ .Default}})
+{{ .Returning}}
+{{ .Ws}}id,
+{{ .Ws}}name {{ .As}} "person";
\ No newline at end of file
@@ -0,0 +1,15 @@
+{{ .With}} data {{ .As}} (
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}*
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab
+)
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}tab7 (id, name)
+{{ .Select}}
+{{ .Ws}}d.id,
+{{ .Ws}}d.name
+{{ .From}}
+{{ .Ws}}data d
+{{ .Where}}
+{{ .Ws}}d.active;
\ No newline at end of file
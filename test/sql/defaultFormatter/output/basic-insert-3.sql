@@ -0,0 +1,12 @@
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}tab7 {{ .As}} t (id, qty, tags[1])
+{{ .Values}}
+{{ .Ws}}(?, ?, 'new')
+{{ .On}} {{ .Conflict}} (id) {{ .Do}} {{ .Update}}
+{{ .Set}}
+{{ .Ws}}qty = t.qty + excluded.qty,
+{{ .Ws}}tags[1] = excluded.tags[1]
+{{ .Where}}
+{{ .Ws}}{{ .Not}} t.locked
+{{ .Returning}}
+{{ .Ws}}*;
\ No newline at end of file
@@ -0,0 +1,5 @@
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}tab7 (id)
+{{ .Values}}
+{{ .Ws}}(1)
+{{ .On}} {{ .Conflict}} ({{ .Fn "lower"}}(name), (id + 1)) {{ .Where}} active {{ .Do}} {{ .Nothing}};
\ No newline at end of file
@@ -0,0 +1,3 @@
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}tab7
+{{ .Default}} {{ .Values}};
\ No newline at end of file
@@ -17,4 +17,4 @@ other_data {{ .As}} (
 {{ .Join}}
 {{ .Ws}}other_data od
 {{ .Ws}}{{ .On}}
-{{ .Ws}}{{ .Ws}}od.id = d.id
\ No newline at end of file
+{{ .Ws}}{{ .Ws}}od.id = d.id;
\ No newline at end of file
@@ -5,4 +5,4 @@
 {{ .Order}} {{ .By}}
 {{ .Ws}}id {{ .Desc}}
 {{ .Limit}}
-{{ .Ws}}5
\ No newline at end of file
+{{ .Ws}}5;
\ No newline at end of file
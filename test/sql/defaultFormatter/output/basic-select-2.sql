@@ -4,4 +4,4 @@
 {{ .Ws}}c,
 {{ .Ws}}d
 {{ .From}}
-{{ .Ws}}some_schema.tab7
\ No newline at end of file
+{{ .Ws}}some_schema.tab7;
\ No newline at end of file
@@ -4,4 +4,4 @@
 {{ .Ws}}c,
 {{ .Ws}}d
 {{ .From}}
-{{ .Ws}}tab7
\ No newline at end of file
+{{ .Ws}}tab7;
\ No newline at end of file
@@ -17,4 +17,4 @@
 {{ .Ws}}{{ .Ws}}{{ .Ws}}pers_no = t7.person_id
 {{ .Ws}}) {{ .As}} "fired"
 {{ .From}}
-{{ .Ws}}tab7 t7
\ No newline at end of file
+{{ .Ws}}tab7 t7;
\ No newline at end of file
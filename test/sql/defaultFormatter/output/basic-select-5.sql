@@ -5,4 +5,4 @@
 {{ .Join}}
 {{ .Ws}}tab2 t2
 {{ .Ws}}{{ .On}}
-{{ .Ws}}{{ .Ws}}t2.day = g.d
\ No newline at end of file
+{{ .Ws}}{{ .Ws}}t2.day = g.d;
\ No newline at end of file
@@ -1,4 +1,4 @@
 {{ .Select}}
 {{ .Ws}}{{ .Fn "count"}}(1)
 {{ .From}}
-{{ .Ws}}tab7
\ No newline at end of file
+{{ .Ws}}tab7;
\ No newline at end of file
@@ -20,4 +20,4 @@
 {{ .Group}} {{ .By}}
 {{ .Ws}}t7.id
 {{ .Having}}
-{{ .Ws}}3 > 11
\ No newline at end of file
+{{ .Ws}}3 > 11;
\ No newline at end of file
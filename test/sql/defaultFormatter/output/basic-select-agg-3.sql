@@ -9,4 +9,4 @@
 {{ .Ws}}t7.g2
 {{ .Order}} {{ .By}}
 {{ .Ws}}t7.g2 {{ .Desc}},
-{{ .Ws}}t7.g1 {{ .Nulls}} {{ .Last}}
\ No newline at end of file
+{{ .Ws}}t7.g1 {{ .Nulls}} {{ .Last}};
\ No newline at end of file
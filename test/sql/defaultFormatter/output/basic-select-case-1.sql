@@ -0,0 +1,36 @@
+{{ .Select}}
+{{ .Ws}}t7.id,
+{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .When}} t7.score > 90 {{ .Then}} 'a'
+{{ .Ws}}{{ .Ws}}{{ .When}} t7.score > 80
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .And}} t7.bonus {{ .Then}} 'b'
+{{ .Ws}}{{ .Ws}}{{ .Else}} 'c'
+{{ .Ws}}{{ .End}} {{ .As}} "grade",
+{{ .Ws}}{{ .Case}} t7.status
+{{ .Ws}}{{ .Ws}}{{ .When}} 1 {{ .Then}} 'new'
+{{ .Ws}}{{ .Ws}}{{ .When}} 2 {{ .Then}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .When}} t7.late {{ .Then}} 'late'
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Else}} 'on time'
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .End}}
+{{ .Ws}}{{ .End}} {{ .As}} "status",
+{{ .Ws}}{{ .Fn "ifnull"}}(
+{{ .Ws}}{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .When}} t7.x {{ .Then}} t7.y
+{{ .Ws}}{{ .Ws}}{{ .End}},
+{{ .Ws}}{{ .Ws}}0
+{{ .Ws}}) {{ .As}} "val",
+{{ .Ws}}{{ .Fn "sum"}}(
+{{ .Ws}}{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .When}} t7.ok {{ .Then}} 1
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Else}} 0
+{{ .Ws}}{{ .Ws}}{{ .End}}
+{{ .Ws}}) {{ .As}} "oks"
+{{ .From}}
+{{ .Ws}}tab7 t7
+{{ .Where}}
+{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .When}} t7.kind = 'x' {{ .Then}} t7.a
+{{ .Ws}}{{ .Ws}}{{ .Else}} t7.b
+{{ .Ws}}{{ .End}} > 10
+{{ .Ws}}{{ .And}} t7.name = {{ .Fn "ifnull"}}(t7.alias, 'none');
\ No newline at end of file
@@ -0,0 +1,17 @@
+{{ .Select}}
+{{ .Ws}}{{ .Fn "coalesce"}}(a, b),
+{{ .Ws}}{{ .Fn "greatest"}}(a, b, 0),
+{{ .Ws}}{{ .Fn "least"}}(
+{{ .Ws}}{{ .Ws}}a,
+{{ .Ws}}{{ .Ws}}{{ .Fn "coalesce"}}(
+{{ .Ws}}{{ .Ws}}{{ .Ws}}b,
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .When}} c {{ .Then}} 1
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Else}} 2
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .End}}
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}})
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}{{ .Fn "coalesce"}}(t.a, t.b) > 0;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- monthly revenue per account
+-- owner: billing
+{{ .Select}}
+{{ .Ws}}a.id, -- the account
+{{ .Ws}}/* total */ {{ .Fn "sum"}}(i.amount) {{ .As}} "total",
+{{ .Ws}}{{ .Fn "count"}}(*) -- number of invoices
+{{ .From}}
+{{ .Ws}}accounts a -- all accounts
+{{ .Join}}
+{{ .Ws}}invoices i
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}i.account_id = a.id
+{{ .Where}}
+{{ .Ws}}i.paid
+{{ .Ws}}-- only this year
+{{ .Ws}}{{ .And}} i.year = 2020 /* hard coded */
+{{ .Group}} {{ .By}}
+{{ .Ws}}a.id;
+/* trailing
+   block */
\ No newline at end of file
@@ -0,0 +1,24 @@
+{{ .With}} recent {{ .As}} ( -- last week
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}*
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}events
+{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}/* all */ ts > {{ .Fn "now"}}() - '7 days'::interval
+)
+{{ .Select}}
+{{ .Ws}}id,
+{{ .Ws}}{{ .Fn "ifnull"}}(a, b) -- fallback
+{{ .From}}
+{{ .Ws}}recent
+{{ .Where}}
+{{ .Ws}}id > /* two */ 2 -- ids
+{{ .Order}} {{ .By}}
+{{ .Ws}}id {{ .Desc}}; -- newest first
+{{ .Select}}
+{{ .Ws}}1; -- one
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}t (a, b) -- cols
+{{ .Values}}
+{{ .Ws}}(1, 2), -- row 1
+{{ .Ws}}(3, 4);
\ No newline at end of file
@@ -0,0 +1,12 @@
+{{ .Select}}
+{{ .Ws}}a /* inline */ + b,
+{{ .Ws}}c
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}x = /* why */ 1
+{{ .Ws}}{{ .And}} y
+{{ .Ws}}/* before and */ {{ .And}} z;
+{{ .Select}}
+{{ .Ws}}2;
+-- end
\ No newline at end of file
@@ -0,0 +1,18 @@
+{{ .Select}}
+{{ .Ws}}a, -- first
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Fn "max"}}(b /* in */)
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}u /* last */ -- second
+{{ .Ws}}) {{ .As}} "m",
+{{ .Ws}}d -- third
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}x = {{ .Fn "coalesce"}}(1, 2 /* two */) -- fourth
+{{ .Ws}}{{ .And}} y = (
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}1
+{{ .Ws}}{{ .Ws}}{{ .Ws}}/* own line */
+{{ .Ws}}); -- fifth
\ No newline at end of file
@@ -0,0 +1,22 @@
+{{ .Select}}
+{{ .Ws}}region,
+{{ .Ws}}product,
+{{ .Ws}}{{ .Fn "grouping"}}(region, product) {{ .As}} "level",
+{{ .Ws}}{{ .Fn "sum"}}(amount) {{ .As}} "total"
+{{ .From}}
+{{ .Ws}}sales
+{{ .Group}} {{ .By}}
+{{ .Ws}}{{ .Grouping}} {{ .Sets}} (
+{{ .Ws}}{{ .Ws}}(region, product),
+{{ .Ws}}{{ .Ws}}region,
+{{ .Ws}}{{ .Ws}}()
+{{ .Ws}}),
+{{ .Ws}}{{ .Rollup}}(region, product),
+{{ .Ws}}{{ .Cube}}(
+{{ .Ws}}{{ .Ws}}region,
+{{ .Ws}}{{ .Ws}}(product, channel)
+{{ .Ws}}),
+{{ .Ws}}{{ .Grouping}} {{ .Sets}} (
+{{ .Ws}}{{ .Ws}}{{ .Rollup}}(year, month),
+{{ .Ws}}{{ .Ws}}{{ .Cube}}(region, product)
+{{ .Ws}});
\ No newline at end of file
@@ -12,4 +12,4 @@
 {{ .Left}} {{ .Join}}
 {{ .Ws}}tab9 t9
 {{ .Ws}}{{ .On}}
-{{ .Ws}}{{ .Ws}}t9.id = t8.id
\ No newline at end of file
+{{ .Ws}}{{ .Ws}}t9.id = t8.id;
\ No newline at end of file
@@ -34,4 +34,4 @@
 {{ .Ws}}{{ .Ws}}{{ .Ws}}id = t7.id
 {{ .Ws}}) t2
 {{ .Ws}}{{ .On}}
-{{ .Ws}}{{ .Ws}}true
\ No newline at end of file
+{{ .Ws}}{{ .Ws}}true;
\ No newline at end of file
@@ -4,4 +4,4 @@
 {{ .Ws}}t8.k1
 {{ .From}}
 {{ .Ws}}tab7 t7,
-{{ .Ws}}tab8 t8
\ No newline at end of file
+{{ .Ws}}tab8 t8;
\ No newline at end of file
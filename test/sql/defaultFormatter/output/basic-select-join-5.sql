@@ -21,4 +21,4 @@
 {{ .Full}} {{ .Join}}
 {{ .Ws}}tab8 t8
 {{ .Ws}}{{ .On}}
-{{ .Ws}}{{ .Ws}}t8.id = t7.id
\ No newline at end of file
+{{ .Ws}}{{ .Ws}}t8.id = t7.id;
\ No newline at end of file
@@ -0,0 +1,38 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}a
+{{ .Natural}} {{ .Join}}
+{{ .Ws}}b
+{{ .Natural}} {{ .Left}} {{ .Join}}
+{{ .Ws}}c
+{{ .Join}}
+{{ .Ws}}d
+{{ .Ws}}{{ .Using}}
+{{ .Ws}}{{ .Ws}}(id, name)
+{{ .Left}} {{ .Join}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}e
+{{ .Ws}}{{ .Join}}
+{{ .Ws}}{{ .Ws}}f
+{{ .Ws}}{{ .Ws}}{{ .Using}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}(id)
+{{ .Ws}})
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}f.id = a.id
+{{ .Join}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}g
+{{ .Ws}}{{ .Natural}} {{ .Join}}
+{{ .Ws}}{{ .Ws}}h
+{{ .Ws}}) gh
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}gh.id = a.id
+{{ .Cross}} {{ .Join}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}i
+{{ .Ws}}{{ .Join}}
+{{ .Ws}}{{ .Ws}}j
+{{ .Ws}}{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}j.id = i.id
+{{ .Ws}});
\ No newline at end of file
@@ -0,0 +1,9 @@
+{{ .Select}}
+{{ .Ws}}a
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}a {{ .Not}} {{ .Like}} 'x%'
+{{ .Ws}}{{ .And}} b {{ .Not}} {{ .Ilike}} 'y%'
+{{ .Ws}}{{ .And}} c {{ .Like}} 'z%'
+{{ .Ws}}{{ .And}} d {{ .Ilike}} 'w%';
\ No newline at end of file
@@ -0,0 +1,13 @@
+{{ .Select}}
+{{ .Ws}}id
+{{ .From}}
+{{ .Ws}}jobs
+{{ .Where}}
+{{ .Ws}}state = 'queued'
+{{ .Order}} {{ .By}}
+{{ .Ws}}created_at
+{{ .Limit}}
+{{ .Ws}}10
+{{ .Offset}}
+{{ .Ws}}20
+{{ .For}} {{ .Update}} {{ .Skip}} {{ .Locked}};
\ No newline at end of file
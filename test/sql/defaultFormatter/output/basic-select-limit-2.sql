@@ -0,0 +1,16 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}jobs j
+{{ .Join}}
+{{ .Ws}}queues q
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}q.id = j.queue_id
+{{ .Order}} {{ .By}}
+{{ .Ws}}j.id
+{{ .Offset}}
+{{ .Ws}}5
+{{ .Fetch}} {{ .First}}
+{{ .Ws}}3 {{ .Rows}} {{ .Only}}
+{{ .For}} {{ .No}} {{ .Key}} {{ .Update}} {{ .Of}} j {{ .Nowait}}
+{{ .For}} {{ .Key}} {{ .Share}} {{ .Of}} q;
\ No newline at end of file
@@ -0,0 +1,8 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}t.id > 100
+{{ .Limit}}
+{{ .Ws}}{{ .All}};
\ No newline at end of file
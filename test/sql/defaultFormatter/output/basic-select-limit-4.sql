@@ -0,0 +1,19 @@
+{{ .Select}}
+{{ .Ws}}fetch_next,
+{{ .Ws}}'fetch' {{ .As}} "label"
+{{ .From}}
+{{ .Ws}}jobs
+{{ .Where}}
+{{ .Ws}}state <> 'limit'
+{{ .Order}} {{ .By}}
+{{ .Ws}}id
+{{ .Limit}}
+{{ .Ws}}5;
+{{ .Select}}
+{{ .Ws}}id {{ .As}} "fetch"
+{{ .From}}
+{{ .Ws}}jobs -- limit
+{{ .Offset}}
+{{ .Ws}}2
+{{ .Fetch}} {{ .First}}
+{{ .Ws}}(3 + 1) {{ .Rows}} {{ .Only}};
\ No newline at end of file
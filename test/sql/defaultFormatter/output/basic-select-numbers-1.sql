@@ -0,0 +1,17 @@
+{{ .Select}}
+{{ .Ws}}1e-10,
+{{ .Ws}}3.14159265358979,
+{{ .Ws}}123456789012345678901234567890.123,
+{{ .Ws}}1.50,
+{{ .Ws}}1E5,
+{{ .Ws}}7,
+{{ .Ws}}-2.5,
+{{ .Ws}}9223372036854775808,
+{{ .Ws}}1.0,
+{{ .Ws}}.5,
+{{ .Ws}}00.500E+03,
+{{ .Ws}}2.5e-07
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}price > 10.00;
\ No newline at end of file
@@ -0,0 +1,11 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}o.customer_id = $1
+{{ .Ws}}{{ .And}} o.created_at >= $2
+{{ .Ws}}{{ .And}} o.status <> $3
+{{ .Ws}}{{ .And}} o.assigned_to = $1
+{{ .Limit}}
+{{ .Ws}}$4;
\ No newline at end of file
@@ -0,0 +1,8 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}o.customer_id = ?customerID
+{{ .Ws}}{{ .And}} o.created_at >= ?since::date
+{{ .Ws}}{{ .And}} o.assigned_to = ?customerID;
\ No newline at end of file
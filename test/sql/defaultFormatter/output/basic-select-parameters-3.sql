@@ -0,0 +1,17 @@
+{{ .Select}}
+{{ .Ws}}?Columns2,
+{{ .Ws}}E'it''s ?not',
+{{ .Ws}}' ?nope ',
+{{ .Ws}}' :nope ' -- ?comment
+{{ .From}}
+{{ .Ws}}t /* ?x /* nested ?y */ */
+{{ .Where}}
+{{ .Ws}}a = ?
+{{ .Ws}}{{ .And}} b = ?name
+{{ .Ws}}{{ .And}} c {{ .Like}} '%?%'
+{{ .Ws}}{{ .And}} d = ?0
+{{ .Ws}}{{ .And}} e = :sqlx
+{{ .Ws}}{{ .And}} f = arr[1:n]
+{{ .Ws}}{{ .And}} g = ?name
+{{ .Ws}}{{ .And}} h = $1
+{{ .Ws}}{{ .And}} {{ .Fn "now"}}()::date > i;
\ No newline at end of file
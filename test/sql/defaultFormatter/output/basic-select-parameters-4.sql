@@ -0,0 +1,15 @@
+{{ .Select}}
+{{ .Ws}}?Columns,
+{{ .Ws}}t.?IdColumn,
+{{ .Ws}}{{ .Fn "count"}}(*) {{ .As}} ?CountAlias
+{{ .From}}
+{{ .Ws}}?TableName t
+{{ .Join}}
+{{ .Ws}}?Schema.orders o
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}o.?ForeignKey = t.id
+{{ .Where}}
+{{ .Ws}}t.tenant = ?tenantID
+{{ .Ws}}{{ .And}} t.kind {{ .Like}} '%?%'
+{{ .Group}} {{ .By}}
+{{ .Ws}}?Columns;
\ No newline at end of file
@@ -0,0 +1,21 @@
+{{ .Select}}
+{{ .Ws}}@ -1,
+{{ .Ws}}@ -1.5,
+{{ .Ws}}@ -x,
+{{ .Ws}}|/ -4::double precision,
+{{ .Ws}}~ -a
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}a
+{{ .Ws}}{{ .Ws}}{{ .And}} {{ .Not}} d
+{{ .Ws}}{{ .Ws}}{{ .And}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}b
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .And}} c
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}})
+{{ .Ws}}{{ .Or}} (
+{{ .Ws}}{{ .Ws}}e
+{{ .Ws}}{{ .Ws}}{{ .Or}} f
+{{ .Ws}});
\ No newline at end of file
@@ -0,0 +1,17 @@
+{{ .Select}}
+{{ .Ws}}E'a\nb',
+{{ .Ws}}'O''Brien',
+{{ .Ws}}'x',
+{{ .Ws}}B'101',
+{{ .Ws}}X'1F',
+{{ .Ws}}'dat',
+{{ .Ws}}'C:\temp',
+{{ .Ws}}E'it''s \\ ok',
+{{ .Ws}}'multi
+line',
+{{ .Ws}}'has $$ inside and a quote '' here',
+{{ .Ws}}'ends with $'
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}name = 'O''Reilly';
\ No newline at end of file
@@ -0,0 +1,9 @@
+{{ .Select}}
+{{ .Ws}}x::"char",
+{{ .Ws}}y::"MyType",
+{{ .Ws}}z::public."Weird Type",
+{{ .Ws}}a::pg_catalog."char",
+{{ .Ws}}b::pg_catalog.int4,
+{{ .Ws}}c::timestamptz(3),
+{{ .Ws}}d::interval {{ .Day}} {{ .To}} {{ .Second}},
+{{ .Ws}}e::"select"[];
\ No newline at end of file
@@ -0,0 +1,10 @@
+{{ .Select}}
+{{ .Ws}}x::timestamptz,
+{{ .Ws}}y::pg_catalog.int4,
+{{ .Ws}}z::integer,
+{{ .Ws}}w::timestamptz(3),
+{{ .Ws}}v::timestamp(2) {{ .With}} {{ .Time}} {{ .Zone}},
+{{ .Ws}}u::pg_catalog.timestamptz,
+{{ .Ws}}t::"interval",
+{{ .Ws}}q::pg_catalog.int8,
+{{ .Ws}}'t'::pg_catalog.bool;
\ No newline at end of file
@@ -20,4 +20,4 @@
 {{ .Ws}}{{ .Ws}})
 {{ .Ws}})
 {{ .Ws}}{{ .And}} x = 22
-{{ .Ws}}{{ .And}} t.name {{ .Ilike}} '%bob%'
\ No newline at end of file
+{{ .Ws}}{{ .And}} t.name {{ .Ilike}} '%bob%';
\ No newline at end of file
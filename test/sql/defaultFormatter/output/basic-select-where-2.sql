@@ -21,4 +21,4 @@
 {{ .Ws}}{{ .Ws}}{{ .Ws}}t.opt3
 {{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .And}} t.opt4
 {{ .Ws}}{{ .Ws}})
-{{ .Ws}})
\ No newline at end of file
+{{ .Ws}});
\ No newline at end of file
@@ -48,4 +48,4 @@
 {{ .Ws}}{{ .Ws}}{{ .Ws}}x23423z
 {{ .Ws}}{{ .Ws}}{{ .Where}}
 {{ .Ws}}{{ .Ws}}{{ .Ws}}id = t2.id
-{{ .Ws}})
\ No newline at end of file
+{{ .Ws}});
\ No newline at end of file
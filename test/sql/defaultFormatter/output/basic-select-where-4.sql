@@ -4,4 +4,4 @@
 {{ .Ws}}tab7 t7
 {{ .Where}}
 {{ .Ws}}t7.kids = {{ .Any}}(t7.parents)
-{{ .Ws}}{{ .Or}} t7.parents = {{ .All}}(t7.kids)
\ No newline at end of file
+{{ .Ws}}{{ .Or}} t7.parents = {{ .All}}(t7.kids);
\ No newline at end of file
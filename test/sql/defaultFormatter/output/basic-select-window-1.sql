@@ -0,0 +1,34 @@
+{{ .Select}}
+{{ .Ws}}id,
+{{ .Ws}}{{ .Fn "row_number"}}() {{ .Over}} ({{ .Partition}} {{ .By}} t7.grp {{ .Order}} {{ .By}} t7.created {{ .Desc}}) {{ .As}} "rn",
+{{ .Ws}}{{ .Fn "sum"}}(t7.qty) {{ .Over}} w {{ .As}} "running",
+{{ .Ws}}{{ .Fn "avg"}}(t7.qty) {{ .Over}} (w {{ .Rows}} {{ .Between}} 3 {{ .Preceding}} {{ .And}} {{ .Current}} {{ .Row}}),
+{{ .Ws}}{{ .Fn "count"}}(*)
+{{ .Ws}}{{ .Ws}}{{ .Filter}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.ok
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}}{{ .Ws}}{{ .Over}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Partition}} {{ .By}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.grp,
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.sub_grp,
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.region
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Order}} {{ .By}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.created,
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.id
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Range}} {{ .Between}} {{ .Unbounded}} {{ .Preceding}} {{ .And}} {{ .Unbounded}} {{ .Following}}
+{{ .Ws}}{{ .Ws}}) {{ .As}} "cnt",
+{{ .Ws}}{{ .Fn "string_agg"}}(t7.name, ',' {{ .Order}} {{ .By}} t7.name) {{ .As}} "all_names"
+{{ .From}}
+{{ .Ws}}tab7 t7
+{{ .Window}}
+{{ .Ws}}w {{ .As}} (
+{{ .Ws}}{{ .Ws}}{{ .Partition}} {{ .By}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.grp
+{{ .Ws}}{{ .Ws}}{{ .Order}} {{ .By}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}t7.created
+{{ .Ws}}{{ .Ws}}{{ .Rows}} {{ .Unbounded}} {{ .Preceding}}
+{{ .Ws}}),
+{{ .Ws}}w2 {{ .As}} (w)
+{{ .Order}} {{ .By}}
+{{ .Ws}}rn;
\ No newline at end of file
@@ -0,0 +1,23 @@
+{{ .Select}}
+{{ .Ws}}id,
+{{ .Ws}}name
+{{ .From}}
+{{ .Ws}}tab7
+{{ .Union}} {{ .All}}
+{{ .Select}}
+{{ .Ws}}id,
+{{ .Ws}}name
+{{ .From}}
+{{ .Ws}}tab8
+{{ .Where}}
+{{ .Ws}}active
+{{ .Union}}
+{{ .Select}}
+{{ .Ws}}id,
+{{ .Ws}}name
+{{ .From}}
+{{ .Ws}}tab9
+{{ .Order}} {{ .By}}
+{{ .Ws}}name {{ .Desc}}
+{{ .Limit}}
+{{ .Ws}}10;
\ No newline at end of file
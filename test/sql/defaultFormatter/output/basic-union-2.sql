@@ -0,0 +1,27 @@
+(
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab7
+{{ .Ws}}{{ .Union}}
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab8
+)
+{{ .Intersect}}
+(
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab9
+{{ .Ws}}{{ .Order}} {{ .By}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Limit}}
+{{ .Ws}}{{ .Ws}}5
+)
+{{ .Except}} {{ .All}}
+{{ .Select}}
+{{ .Ws}}id
+{{ .From}}
+{{ .Ws}}tab10;
\ No newline at end of file
@@ -0,0 +1,27 @@
+{{ .With}} a {{ .As}} (
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab7
+{{ .Ws}}{{ .Intersect}}
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab8
+)
+{{ .Select}}
+{{ .Ws}}id
+{{ .From}}
+{{ .Ws}}a
+{{ .Except}}
+(
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab9
+{{ .Ws}}{{ .Except}}
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab10
+);
\ No newline at end of file
@@ -0,0 +1,35 @@
+(
+{{ .Ws}}{{ .With}} x {{ .As}} (
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}tab7
+{{ .Ws}})
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}x
+)
+{{ .Union}}
+{{ .Select}}
+{{ .Ws}}id
+{{ .From}}
+{{ .Ws}}tab8;
+(
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab7
+)
+{{ .Union}} {{ .All}}
+(
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab8
+)
+{{ .Except}}
+{{ .Select}}
+{{ .Ws}}id
+{{ .From}}
+{{ .Ws}}tab9;
\ No newline at end of file
@@ -0,0 +1,11 @@
+{{ .Update}}
+{{ .Ws}}some_schema.tab7 t7
+{{ .Set}}
+{{ .Ws}}name = 'bob',
+{{ .Ws}}score = t7.score + 1,
+{{ .Ws}}updated = {{ .Fn "now"}}()
+{{ .Where}}
+{{ .Ws}}t7.id = ?
+{{ .Returning}}
+{{ .Ws}}t7.id,
+{{ .Ws}}t7.score {{ .As}} "new_score";
\ No newline at end of file
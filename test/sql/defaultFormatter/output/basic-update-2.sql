@@ -0,0 +1,21 @@
+{{ .Update}}
+{{ .Ws}}tab7 t7
+{{ .Set}}
+{{ .Ws}}(qty, total) = (
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Fn "sum"}}(t8.qty),
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Fn "sum"}}(t8.total)
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}tab8 t8
+{{ .Ws}}{{ .Ws}}{{ .Where}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}t8.tab7_id = t7.id
+{{ .Ws}}),
+{{ .Ws}}tags[2] = 'x',
+{{ .Ws}}(a, b) = (1, 2)
+{{ .Where}}
+{{ .Ws}}t7.id = (
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Fn "max"}}(id)
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}tab7
+{{ .Ws}});
\ No newline at end of file
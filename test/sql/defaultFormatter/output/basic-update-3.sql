@@ -0,0 +1,19 @@
+{{ .With}} data {{ .As}} (
+{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}*
+{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}tab
+)
+{{ .Update}}
+{{ .Ws}}tab7 t7
+{{ .Set}}
+{{ .Ws}}qty = d.qty
+{{ .From}}
+{{ .Ws}}data d
+{{ .Join}}
+{{ .Ws}}tab8 t8
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}t8.id = d.id
+{{ .Where}}
+{{ .Ws}}d.id = t7.id
+{{ .Ws}}{{ .And}} t8.active;
\ No newline at end of file
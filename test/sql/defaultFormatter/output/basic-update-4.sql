@@ -0,0 +1,6 @@
+{{ .Update}}
+{{ .Ws}}tab7
+{{ .Set}}
+{{ .Ws}}active = 1
+{{ .Where}}
+{{ .Ws}}{{ .Current}} {{ .Of}} some_cursor;
\ No newline at end of file
@@ -0,0 +1,9 @@
+{{ .Update}}
+{{ .Ws}}orders
+{{ .Set}}
+{{ .Ws}}status = :status,
+{{ .Ws}}updated_at = {{ .Fn "now"}}()::timestamp
+{{ .Where}}
+{{ .Ws}}id = :id
+{{ .Returning}}
+{{ .Ws}}id;
\ No newline at end of file
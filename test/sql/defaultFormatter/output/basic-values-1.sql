@@ -0,0 +1,8 @@
+{{ .Values}}
+{{ .Ws}}(1, 'one', {{ .Fn "now"}}()),
+{{ .Ws}}(2, 'two', {{ .Null}}),
+{{ .Ws}}(3, 'three', {{ .Fn "now"}}())
+{{ .Order}} {{ .By}}
+{{ .Ws}}1 {{ .Desc}}
+{{ .Limit}}
+{{ .Ws}}2;
\ No newline at end of file
@@ -0,0 +1,14 @@
+{{ .Select}}
+{{ .Ws}}v.id,
+{{ .Ws}}v.name,
+{{ .Ws}}t7.score
+{{ .From}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}{{ .Values}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}(1, 'bob'),
+{{ .Ws}}{{ .Ws}}{{ .Ws}}(22, 'alice')
+{{ .Ws}}) v(id, name)
+{{ .Join}}
+{{ .Ws}}tab7 t7
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}t7.name = v.name;
\ No newline at end of file
@@ -0,0 +1,12 @@
+{{ .With}} data {{ .As}} (
+{{ .Ws}}{{ .Values}}
+{{ .Ws}}{{ .Ws}}(1, 2),
+{{ .Ws}}{{ .Ws}}(3, 4)
+)
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}data
+{{ .Union}} {{ .All}}
+{{ .Values}}
+{{ .Ws}}(5, 6);
\ No newline at end of file
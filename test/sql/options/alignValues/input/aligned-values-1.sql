@@ -0,0 +1,2 @@
+insert into tab7 (id, name, score)
+values (1, 'bob', 42), (200, 'alice', null), (3, 'eve', lower('X'))
\ No newline at end of file
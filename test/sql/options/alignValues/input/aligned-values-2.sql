@@ -0,0 +1,2 @@
+select *
+from (values (1, 'a', 7), (1000, 'bbbbb', 8), (10, (select 1), 9)) v(id, name, flag)
\ No newline at end of file
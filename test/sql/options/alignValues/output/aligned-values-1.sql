@@ -0,0 +1,6 @@
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}tab7 (id, name, score)
+{{ .Values}}
+{{ .Ws}}(1,   'bob',   42),
+{{ .Ws}}(200, 'alice', {{ .Null}}),
+{{ .Ws}}(3,   'eve',   {{ .Fn "lower"}}('X'));
\ No newline at end of file
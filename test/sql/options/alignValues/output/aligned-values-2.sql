@@ -0,0 +1,12 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}{{ .Values}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}(1, 'a', 7),
+{{ .Ws}}{{ .Ws}}{{ .Ws}}(1000, 'bbbbb', 8),
+{{ .Ws}}{{ .Ws}}{{ .Ws}}(10, (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .Ws}}1
+{{ .Ws}}{{ .Ws}}{{ .Ws}}), 9)
+{{ .Ws}}) v(id, name, flag);
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- accounts
+insert into accounts (id, name) values (1, 'a');
+insert into accounts (id, name) values (2, 'b'); update accounts set name = 'c' where id = 2;
+
+
+/* clean up */
+
+delete from accounts where id = 1;
+select count(*) from accounts
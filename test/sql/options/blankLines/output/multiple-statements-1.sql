@@ -0,0 +1,28 @@
+-- accounts
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}accounts (id, name)
+{{ .Values}}
+{{ .Ws}}(1, 'a');
+{{ .Insert}} {{ .Into}}
+{{ .Ws}}accounts (id, name)
+{{ .Values}}
+{{ .Ws}}(2, 'b');
+{{ .Update}}
+{{ .Ws}}accounts
+{{ .Set}}
+{{ .Ws}}name = 'c'
+{{ .Where}}
+{{ .Ws}}id = 2;
+
+
+/* clean up */
+
+
+{{ .Delete}} {{ .From}}
+{{ .Ws}}accounts
+{{ .Where}}
+{{ .Ws}}id = 1;
+{{ .Select}}
+{{ .Ws}}{{ .Fn "count"}}(*)
+{{ .From}}
+{{ .Ws}}accounts;
\ No newline at end of file
@@ -0,0 +1 @@
+select ?Columns, o.id as ?Alias from ?Schema.?TableName o where o.customer_id = ?customerID order by ?0
\ No newline at end of file
@@ -0,0 +1 @@
+select ?alias.id, i.?itemID from ?Orders ?alias join (select id from items) i(?itemID) on i.?itemID = ?alias.id
\ No newline at end of file
@@ -0,0 +1,11 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}o.customer_id = :p1
+{{ .Ws}}{{ .And}} o.created_at >= :p2
+{{ .Ws}}{{ .And}} o.status <> :p3
+{{ .Ws}}{{ .And}} o.assigned_to = :p1
+{{ .Limit}}
+{{ .Ws}}:p4;
\ No newline at end of file
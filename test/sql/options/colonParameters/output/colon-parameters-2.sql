@@ -0,0 +1,9 @@
+{{ .Select}}
+{{ .Ws}}:Columns,
+{{ .Ws}}o.id {{ .As}} :Alias
+{{ .From}}
+{{ .Ws}}:Schema.:TableName o
+{{ .Where}}
+{{ .Ws}}o.customer_id = :customerID
+{{ .Order}} {{ .By}}
+{{ .Ws}}:p1;
\ No newline at end of file
@@ -0,0 +1,14 @@
+{{ .Select}}
+{{ .Ws}}:alias.id,
+{{ .Ws}}i.:itemID
+{{ .From}}
+{{ .Ws}}:Orders :alias
+{{ .Join}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}id
+{{ .Ws}}{{ .Ws}}{{ .From}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}items
+{{ .Ws}}) i(:itemID)
+{{ .Ws}}{{ .On}}
+{{ .Ws}}{{ .Ws}}i.:itemID = :alias.id;
\ No newline at end of file
@@ -0,0 +1,2 @@
+select E'a\nb', 'O''Brien', $$x$$, B'101', X'1F', U&'d\0061t', 'C:\temp', E'it''s \\ ok', 'multi
+line', $q$has $$ inside and a quote ' here$q$, 'ends with $' from t where name = 'O''Reilly'
\ No newline at end of file
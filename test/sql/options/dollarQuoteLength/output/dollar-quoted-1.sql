@@ -0,0 +1,18 @@
+{{ .Select}}
+{{ .Ws}}$$a
+b$$,
+{{ .Ws}}'O''Brien',
+{{ .Ws}}'x',
+{{ .Ws}}B'101',
+{{ .Ws}}X'1F',
+{{ .Ws}}'dat',
+{{ .Ws}}'C:\temp',
+{{ .Ws}}E'it''s \\ ok',
+{{ .Ws}}$$multi
+line$$,
+{{ .Ws}}$q1$has $$ inside and a quote ' here$q1$,
+{{ .Ws}}$q1$ends with $$q1$
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}name = 'O''Reilly';
\ No newline at end of file
@@ -0,0 +1 @@
+select (a + b) * c, a - (b - c), (a - b) - c, -(a + b), - -x, (-1)::int, (price * (1 - discount)) as net, 2 ^ (3 ^ 2), (a || b) like c, (a = b) = (c = d), (a is null) = is_missing, (x in (select 1)) = y, (a and b) = c, (a * b) + c from t where (a + 1) between (b - 1) and (c + 1) and (x = 1 or y = 2) and (z > 3) and not (q = 1)
\ No newline at end of file
@@ -0,0 +1 @@
+select id from t where a and (not d) and (b and c) or not (e) or (not f and g)
\ No newline at end of file
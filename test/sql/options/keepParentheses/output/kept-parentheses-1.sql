@@ -0,0 +1,31 @@
+{{ .Select}}
+{{ .Ws}}(a + b) * c,
+{{ .Ws}}a - (b - c),
+{{ .Ws}}(a - b) - c,
+{{ .Ws}}-(a + b),
+{{ .Ws}}-(-x),
+{{ .Ws}}(-1)::integer,
+{{ .Ws}}price * (1 - discount) {{ .As}} "net",
+{{ .Ws}}2 ^ (3 ^ 2),
+{{ .Ws}}(a || b) {{ .Like}} c,
+{{ .Ws}}(a = b) = (c = d),
+{{ .Ws}}(a {{ .Is}} {{ .Null}}) = is_missing,
+{{ .Ws}}(x {{ .In}}(
+{{ .Ws}}{{ .Ws}}{{ .Select}}
+{{ .Ws}}{{ .Ws}}{{ .Ws}}1
+{{ .Ws}})) = y,
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}a
+{{ .Ws}}{{ .Ws}}{{ .And}} b
+{{ .Ws}}) = c,
+{{ .Ws}}(a * b) + c
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}(a + 1) {{ .Between}} (b - 1) {{ .And}} (c + 1)
+{{ .Ws}}{{ .And}} (
+{{ .Ws}}{{ .Ws}}x = 1
+{{ .Ws}}{{ .Ws}}{{ .Or}} y = 2
+{{ .Ws}})
+{{ .Ws}}{{ .And}} (z > 3)
+{{ .Ws}}{{ .And}} {{ .Not}} (q = 1);
\ No newline at end of file
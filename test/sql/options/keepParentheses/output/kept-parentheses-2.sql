@@ -0,0 +1,18 @@
+{{ .Select}}
+{{ .Ws}}id
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}a
+{{ .Ws}}{{ .Ws}}{{ .And}} ({{ .Not}} d)
+{{ .Ws}}{{ .Ws}}{{ .And}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}b
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .And}} c
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}})
+{{ .Ws}}{{ .Or}} {{ .Not}} (e)
+{{ .Ws}}{{ .Or}} (
+{{ .Ws}}{{ .Ws}}{{ .Not}} f
+{{ .Ws}}{{ .Ws}}{{ .And}} g
+{{ .Ws}});
\ No newline at end of file
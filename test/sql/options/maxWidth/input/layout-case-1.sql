@@ -0,0 +1,7 @@
+select case when a > 1 then 'big' else 'small' end as size,
+  greatest(a, b, c),
+  count(*) filter (where a > 1) over (partition by b order by c) as n,
+  case when a > 100 then 'very big' when a > 10 then 'big' when a > 1 then 'medium' else 'small' end
+from t
+union all
+select 'x', 1, 2, 'y'
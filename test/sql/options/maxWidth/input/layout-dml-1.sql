@@ -0,0 +1,4 @@
+insert into users (id, name) values (1, 'bob');
+update users set name = 'alice', updated = now() where id = 1 returning id, name, updated, created, version;
+delete from users where id = 1;
+with old as (select id from users where created < '2000-01-01') delete from users u using old where u.id = old.id
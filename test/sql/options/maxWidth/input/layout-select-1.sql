@@ -0,0 +1,7 @@
+select 1;
+select id, name from users where id = 42;
+select u.id, coalesce(u.nickname, u.first_name, u.last_name, 'anonymous user') as display_name
+from users u
+where u.active and u.id in (select user_id from logins where logged_in > ?since)
+order by u.id
+limit 10
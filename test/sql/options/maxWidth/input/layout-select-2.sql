@@ -0,0 +1,5 @@
+select o.id, o.total, c.name
+from orders o
+join customers c on c.id = o.customer_id
+left join (select order_id, sum(qty) as qty from order_lines group by order_id) l on l.order_id = o.id
+where o.total > 100 and (o.status = 'open' or o.status = 'pending' and o.created > '2020-01-01')
@@ -0,0 +1,17 @@
+{{ .Select}}
+{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .When}} a > 1 {{ .Then}} 'big'
+{{ .Ws}}{{ .Ws}}{{ .Else}} 'small'
+{{ .Ws}}{{ .End}} {{ .As}} "size",
+{{ .Ws}}{{ .Fn "greatest"}}(a, b, c),
+{{ .Ws}}{{ .Fn "count"}}(*) {{ .Filter}} ({{ .Where}} a > 1)
+{{ .Ws}}{{ .Ws}}{{ .Over}} ({{ .Partition}} {{ .By}} b {{ .Order}} {{ .By}} c) {{ .As}} "n",
+{{ .Ws}}{{ .Case}}
+{{ .Ws}}{{ .Ws}}{{ .When}} a > 100 {{ .Then}} 'very big'
+{{ .Ws}}{{ .Ws}}{{ .When}} a > 10 {{ .Then}} 'big'
+{{ .Ws}}{{ .Ws}}{{ .When}} a > 1 {{ .Then}} 'medium'
+{{ .Ws}}{{ .Ws}}{{ .Else}} 'small'
+{{ .Ws}}{{ .End}}
+{{ .From}} t
+{{ .Union}} {{ .All}}
+{{ .Select}} 'x', 1, 2, 'y';
\ No newline at end of file
@@ -0,0 +1,14 @@
+{{ .Insert}} {{ .Into}} users (id, name) {{ .Values}} (1, 'bob');
+{{ .Update}} users
+{{ .Set}} name = 'alice', updated = {{ .Fn "now"}}()
+{{ .Where}} id = 1
+{{ .Returning}} id, name, updated, created, version;
+{{ .Delete}} {{ .From}} users {{ .Where}} id = 1;
+{{ .With}} old {{ .As}} (
+{{ .Ws}}{{ .Select}} id
+{{ .Ws}}{{ .From}} users
+{{ .Ws}}{{ .Where}} created < '2000-01-01'
+)
+{{ .Delete}} {{ .From}} users u
+{{ .Using}} old
+{{ .Where}} u.id = old.id;
\ No newline at end of file
@@ -0,0 +1,20 @@
+{{ .Select}} 1;
+{{ .Select}} id, name {{ .From}} users {{ .Where}} id = 42;
+{{ .Select}}
+{{ .Ws}}u.id,
+{{ .Ws}}{{ .Fn "coalesce"}}(
+{{ .Ws}}{{ .Ws}}u.nickname,
+{{ .Ws}}{{ .Ws}}u.first_name,
+{{ .Ws}}{{ .Ws}}u.last_name,
+{{ .Ws}}{{ .Ws}}'anonymous user'
+{{ .Ws}}) {{ .As}} "display_name"
+{{ .From}} users u
+{{ .Where}}
+{{ .Ws}}u.active
+{{ .Ws}}{{ .And}} u.id {{ .In}}(
+{{ .Ws}}{{ .Ws}}{{ .Select}} user_id
+{{ .Ws}}{{ .Ws}}{{ .From}} logins
+{{ .Ws}}{{ .Ws}}{{ .Where}} logged_in > ?since
+{{ .Ws}})
+{{ .Order}} {{ .By}} u.id
+{{ .Limit}} 10;
\ No newline at end of file
@@ -0,0 +1,20 @@
+{{ .Select}} o.id, o.total, c.name
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Join}} customers c {{ .On}} c.id = o.customer_id
+{{ .Left}} {{ .Join}}
+{{ .Ws}}(
+{{ .Ws}}{{ .Ws}}{{ .Select}} order_id, {{ .Fn "sum"}}(qty) {{ .As}} "qty"
+{{ .Ws}}{{ .Ws}}{{ .From}} order_lines
+{{ .Ws}}{{ .Ws}}{{ .Group}} {{ .By}} order_id
+{{ .Ws}}) l
+{{ .Ws}}{{ .On}} l.order_id = o.id
+{{ .Where}}
+{{ .Ws}}o.total > 100
+{{ .Ws}}{{ .And}} (
+{{ .Ws}}{{ .Ws}}o.status = 'open'
+{{ .Ws}}{{ .Ws}}{{ .Or}} (
+{{ .Ws}}{{ .Ws}}{{ .Ws}}o.status = 'pending'
+{{ .Ws}}{{ .Ws}}{{ .Ws}}{{ .And}} o.created > '2020-01-01'
+{{ .Ws}}{{ .Ws}})
+{{ .Ws}});
\ No newline at end of file
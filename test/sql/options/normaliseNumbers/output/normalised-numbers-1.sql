@@ -0,0 +1,17 @@
+{{ .Select}}
+{{ .Ws}}1e-10,
+{{ .Ws}}3.14159265358979,
+{{ .Ws}}123456789012345678901234567890.123,
+{{ .Ws}}1.5,
+{{ .Ws}}1e5,
+{{ .Ws}}7,
+{{ .Ws}}-2.5,
+{{ .Ws}}9223372036854775808,
+{{ .Ws}}1.0,
+{{ .Ws}}0.5,
+{{ .Ws}}0.5e3,
+{{ .Ws}}2.5e-7
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}price > 10.0;
\ No newline at end of file
@@ -0,0 +1 @@
+select * from orders o where o.customer_id = ?customerID and o.created_at >= ?since::date and o.assigned_to = ?customerID
\ No newline at end of file
@@ -0,0 +1 @@
+select * from orders o where o.id = $2 and o.customer_id = ?customerID and o.status = ? and o.assigned_to = ?customerID and o.region = ?0
\ No newline at end of file
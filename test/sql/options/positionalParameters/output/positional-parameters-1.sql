@@ -0,0 +1,8 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}o.customer_id = $1
+{{ .Ws}}{{ .And}} o.created_at >= $2::date
+{{ .Ws}}{{ .And}} o.assigned_to = $1;
\ No newline at end of file
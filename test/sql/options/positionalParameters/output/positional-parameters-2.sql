@@ -0,0 +1,9 @@
+{{ .Update}}
+{{ .Ws}}orders
+{{ .Set}}
+{{ .Ws}}status = $1,
+{{ .Ws}}updated_at = {{ .Fn "now"}}()::timestamp
+{{ .Where}}
+{{ .Ws}}id = $2
+{{ .Returning}}
+{{ .Ws}}id;
\ No newline at end of file
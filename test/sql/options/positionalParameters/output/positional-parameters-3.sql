@@ -0,0 +1,10 @@
+{{ .Select}}
+{{ .Ws}}*
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}o.id = $2
+{{ .Ws}}{{ .And}} o.customer_id = $3
+{{ .Ws}}{{ .And}} o.status = $4
+{{ .Ws}}{{ .And}} o.assigned_to = $3
+{{ .Ws}}{{ .And}} o.region = $1;
\ No newline at end of file
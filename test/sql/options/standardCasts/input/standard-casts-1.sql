@@ -0,0 +1 @@
+select a::varchar(20), b::int[], c::numeric(10,2), d::myschema.mytype, e::timestamp with time zone, f::timestamp(3) with time zone, g::interval day to second(3), h::interval(2), i::char, j::double precision, k::int4, l::bit varying(5), m::time with time zone, n::integer[3][], o::interval year, interval '1 day', true, false, 't'::text, 'f'::bool, x::float8, cast(y as text), (a + b)::text
\ No newline at end of file
@@ -0,0 +1,24 @@
+{{ .Select}}
+{{ .Ws}}{{ .Cast}}(a {{ .As}} varchar(20)),
+{{ .Ws}}{{ .Cast}}(b {{ .As}} integer[]),
+{{ .Ws}}{{ .Cast}}(c {{ .As}} numeric(10, 2)),
+{{ .Ws}}{{ .Cast}}(d {{ .As}} myschema.mytype),
+{{ .Ws}}{{ .Cast}}(e {{ .As}} timestamp {{ .With}} {{ .Time}} {{ .Zone}}),
+{{ .Ws}}{{ .Cast}}(f {{ .As}} timestamp(3) {{ .With}} {{ .Time}} {{ .Zone}}),
+{{ .Ws}}{{ .Cast}}(g {{ .As}} interval {{ .Day}} {{ .To}} {{ .Second}}(3)),
+{{ .Ws}}{{ .Cast}}(h {{ .As}} interval(2)),
+{{ .Ws}}{{ .Cast}}(i {{ .As}} char),
+{{ .Ws}}{{ .Cast}}(j {{ .As}} double precision),
+{{ .Ws}}{{ .Cast}}(k {{ .As}} int4),
+{{ .Ws}}{{ .Cast}}(l {{ .As}} bit varying(5)),
+{{ .Ws}}{{ .Cast}}(m {{ .As}} time {{ .With}} {{ .Time}} {{ .Zone}}),
+{{ .Ws}}{{ .Cast}}(n {{ .As}} integer[3][]),
+{{ .Ws}}{{ .Cast}}(o {{ .As}} interval {{ .Year}}),
+{{ .Ws}}{{ .Cast}}('1 day' {{ .As}} interval),
+{{ .Ws}}true,
+{{ .Ws}}false,
+{{ .Ws}}{{ .Cast}}('t' {{ .As}} text),
+{{ .Ws}}{{ .Cast}}('f' {{ .As}} bool),
+{{ .Ws}}{{ .Cast}}(x {{ .As}} float8),
+{{ .Ws}}{{ .Cast}}(y {{ .As}} text),
+{{ .Ws}}{{ .Cast}}(a + b {{ .As}} text);
\ No newline at end of file
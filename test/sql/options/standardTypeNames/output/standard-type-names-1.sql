@@ -0,0 +1,24 @@
+{{ .Select}}
+{{ .Ws}}a::varchar(20),
+{{ .Ws}}b::integer[],
+{{ .Ws}}c::numeric(10, 2),
+{{ .Ws}}d::myschema.mytype,
+{{ .Ws}}e::timestamp {{ .With}} {{ .Time}} {{ .Zone}},
+{{ .Ws}}f::timestamp(3) {{ .With}} {{ .Time}} {{ .Zone}},
+{{ .Ws}}g::interval {{ .Day}} {{ .To}} {{ .Second}}(3),
+{{ .Ws}}h::interval(2),
+{{ .Ws}}i::char,
+{{ .Ws}}j::double precision,
+{{ .Ws}}k::integer,
+{{ .Ws}}l::bit varying(5),
+{{ .Ws}}m::time {{ .With}} {{ .Time}} {{ .Zone}},
+{{ .Ws}}n::integer[3][],
+{{ .Ws}}o::interval {{ .Year}},
+{{ .Ws}}'1 day'::interval,
+{{ .Ws}}true,
+{{ .Ws}}false,
+{{ .Ws}}'t'::text,
+{{ .Ws}}false,
+{{ .Ws}}x::double precision,
+{{ .Ws}}y::text,
+{{ .Ws}}(a + b)::text;
\ No newline at end of file
@@ -0,0 +1,8 @@
+select a, b,
+  (a + b) is distinct from c as d
+from t
+where t.a is not distinct from
+      coalesce(t.b,
+               t.c)
+  and t.id = ?id
+  and x = 1
@@ -0,0 +1 @@
+select o.tags[1], (o.items)[2:3], array[1, 2] as ids from orders o where o.status = any(array['open', 'held']) and o.matrix[1][2] > 0
\ No newline at end of file
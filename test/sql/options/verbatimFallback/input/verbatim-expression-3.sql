@@ -0,0 +1 @@
+select (E'\')' || $$)$$) is distinct from o.note, o.id from orders o where (o.code || E'[\\]') is not distinct from o.key
\ No newline at end of file
@@ -0,0 +1,7 @@
+select o.id,
+       (o.note || 'first line
+      second line
+  third line') is distinct from o.memo,
+       (o.body || $$a
+        b$$) is not distinct from o.text
+  from orders o
\ No newline at end of file
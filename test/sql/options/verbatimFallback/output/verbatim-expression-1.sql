@@ -0,0 +1,12 @@
+{{ .Select}}
+{{ .Ws}}a,
+{{ .Ws}}b,
+{{ .Ws}}(a + b) is distinct from c {{ .As}} "d"
+{{ .From}}
+{{ .Ws}}t
+{{ .Where}}
+{{ .Ws}}t.a is not distinct from
+{{ .Ws}}coalesce(t.b,
+{{ .Ws}}         t.c)
+{{ .Ws}}{{ .And}} t.id = ?id
+{{ .Ws}}{{ .And}} x = 1;
\ No newline at end of file
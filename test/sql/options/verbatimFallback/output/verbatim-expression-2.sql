@@ -0,0 +1,9 @@
+{{ .Select}}
+{{ .Ws}}o.tags[1],
+{{ .Ws}}o.items[2:3],
+{{ .Ws}}array[1, 2] {{ .As}} "ids"
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}o.status = {{ .Any}}(array['open', 'held'])
+{{ .Ws}}{{ .And}} o.matrix[1][2] > 0;
\ No newline at end of file
@@ -0,0 +1,7 @@
+{{ .Select}}
+{{ .Ws}}(E'\')' || $$)$$) is distinct from o.note,
+{{ .Ws}}o.id
+{{ .From}}
+{{ .Ws}}orders o
+{{ .Where}}
+{{ .Ws}}(o.code || E'[\\]') is not distinct from o.key;
\ No newline at end of file
@@ -0,0 +1,9 @@
+{{ .Select}}
+{{ .Ws}}o.id,
+{{ .Ws}}(o.note || 'first line
+      second line
+  third line') is distinct from o.memo,
+{{ .Ws}}(o.body || $$a
+        b$$) is not distinct from o.text
+{{ .From}}
+{{ .Ws}}orders o;
\ No newline at end of file
@@ -0,0 +1,4 @@
+create table  measurements (
+  id    bigint primary key,
+  taken timestamptz not null
+);
\ No newline at end of file
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dbreedt/pgPretty/helpers"
+	"github.com/dbreedt/pgPretty/processors"
+	nodes "github.com/pganalyze/pg_query_go/nodes"
+)
+
+// brokenFormatter Prints the same sql whatever it is given, like a formatter that drops clauses would
+type brokenFormatter struct {
+	sql string
+}
+
+func (bf *brokenFormatter) SetSource(sql string)            {}
+func (bf *brokenFormatter) PrintNode(node nodes.Node) error { return nil }
+func (bf *brokenFormatter) String() string                  { return bf.sql }
+
+func TestVerifyReportsFirstDifference(t *testing.T) {
+	err := processors.Verify("select (a + b) * c from t", "select a + b * c from t")
+
+	var ve *processors.VerifyError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected a VerifyError, got %v", err)
+	}
+
+	expected := "[0].RawStmt.stmt.SelectStmt.targetList[0].ResTarget.val.A_Expr.lexpr.A_Expr"
+	if ve.Path != expected {
+		t.Errorf("expected the difference at %s, got %s", expected, ve.Path)
+	}
+}
+
+func TestVerifyIgnoresFormattingOptions(t *testing.T) {
+	sql, parameters := helpers.ProcessNamedParameters("select ?, ?name, ?name from t where a = 1.50 and b::int4 = 1")
+
+	if err := processors.VerifyWithParameters(sql, parameters, "select $1, :name, $2 from t\nwhere a = 1.5 and cast(b as integer) = 1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyComparesStringConstantsAsWritten(t *testing.T) {
+	sql, parameters := helpers.ProcessNamedParameters(`select o.id from ?orders o where o.code like '?abc%' and "?x" = 1`)
+
+	if err := processors.VerifyWithParameters(sql, parameters, `select o.id from :orders o where o.code like '?abc%' and "?x" = 1`); err != nil {
+		t.Error(err)
+	}
+
+	if err := processors.VerifyWithParameters(sql, parameters, `select o.id from :orders o where o.code like 'abc%' and "?x" = 1`); err == nil {
+		t.Error("expected the changed string constant to be reported")
+	}
+
+	if err := processors.VerifyWithParameters(sql, parameters, `select o.id from :orders o where o.code like '?abc%' and x = 1`); err == nil {
+		t.Error("expected the changed quoted identifier to be reported")
+	}
+}
+
+func TestVerifyComparesParameters(t *testing.T) {
+	formatter := &brokenFormatter{sql: "select a from t where a = $2 and b = $1"}
+
+	if _, err := processors.ProcessSQLWithOptions("select a from t where a = $1 and b = $2", formatter, processors.Options{Verify: true}); err == nil {
+		t.Error("expected the swapped parameters to be reported")
+	}
+
+	sql, parameters := helpers.ProcessNamedParameters("select a from t where a = ?a and b = ?b and c = ?a")
+	formatter = &brokenFormatter{sql: "select a from t where a = $1 and b = $2 and c = $2"}
+
+	if _, err := processors.ProcessSQLWithOptions(sql, formatter, processors.Options{Verify: true, Parameters: parameters}); err == nil {
+		t.Error("expected the merged parameters to be reported")
+	}
+}
+
+func TestVerifyRefusesOutput(t *testing.T) {
+	formatter := &brokenFormatter{sql: "select a from t"}
+
+	out, err := processors.ProcessSQLWithOptions("select a from t where a > 1", formatter, processors.Options{Verify: true})
+	if err == nil {
+		t.Fatal("expected the changed where clause to be reported")
+	}
+
+	if out != "" {
+		t.Errorf("expected no output, got %q", out)
+	}
+}